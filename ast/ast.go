@@ -9,6 +9,8 @@ import (
 type Node interface {
 	TokenLiteral() string
 	String() string
+	Pos() token.Position // 노드의 시작 위치
+	End() token.Position // 노드의 바로 다음 위치
 }
 
 // 명령문 (= 실행 가능한 코드 조각) (= 구문) (= 값이 산출되지 않지만 무언가를 실행함)
@@ -26,6 +28,7 @@ type Expression interface {
 // Program : AST의 루트 노드 (=명령문 집합)
 type Program struct {
 	Statements []Statement
+	Comments   []*CommentGroup // 소스의 모든 주석 (렉서가 ScanComments 모드일 때만 채워짐)
 }
 
 func (program *Program) TokenLiteral() string {
@@ -35,6 +38,20 @@ func (program *Program) TokenLiteral() string {
 	return program.Statements[0].TokenLiteral()
 }
 
+func (program *Program) Pos() token.Position {
+	if len(program.Statements) == 0 {
+		return token.Position{}
+	}
+	return program.Statements[0].Pos()
+}
+
+func (program *Program) End() token.Position {
+	if len(program.Statements) == 0 {
+		return token.Position{}
+	}
+	return program.Statements[len(program.Statements)-1].End()
+}
+
 func (program *Program) String() string {
 	var out bytes.Buffer
 
@@ -54,21 +71,45 @@ type Identifier struct {
 func (i *Identifier) expressionNode()      {}
 func (i *Identifier) TokenLiteral() string { return i.Token.Literal }
 func (i *Identifier) String() string       { return i.Value }
+func (i *Identifier) Pos() token.Position  { return i.Token.Pos }
+func (i *Identifier) End() token.Position  { return i.Token.End }
+
+// 식별자는 값을 그대로 변수에 바인딩하는 패턴으로도 사용됨 (_ 는 바인딩하지 않는 와일드카드)
+func (i *Identifier) patternNode() {}
 
 // LetStatement : LET 구문
 type LetStatement struct {
-	Token token.Token // token.LET 토큰
-	Name  *Identifier // 변수명
-	Value Expression  // 명령문
+	Token   token.Token   // token.LET 토큰 (const 로 선언하면 token.CONST 토큰)
+	Name    *Identifier   // 변수명 (구조 분해 할당이면 nil)
+	Pattern Pattern       // 구조 분해 할당의 패턴 ex) [a, b], {name, age} (변수명 하나면 nil)
+	Value   Expression    // 명령문
+	Doc     *CommentGroup // 바로 윗줄에 붙어있는 문서 주석 (없으면 nil)
 }
 
 func (ls *LetStatement) statementNode()       {}
 func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
+func (ls *LetStatement) Pos() token.Position  { return ls.Token.Pos }
+func (ls *LetStatement) End() token.Position {
+	if ls.Value != nil {
+		return ls.Value.End()
+	}
+	if ls.Name != nil {
+		return ls.Name.End()
+	}
+	if ls.Pattern != nil {
+		return ls.Pattern.End()
+	}
+	return ls.Token.End
+}
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(ls.TokenLiteral() + " ")
-	out.WriteString(ls.Name.String())
+	if ls.Pattern != nil {
+		out.WriteString(ls.Pattern.String())
+	} else {
+		out.WriteString(ls.Name.String())
+	}
 	out.WriteString(" = ")
 
 	if ls.Value != nil {
@@ -80,6 +121,83 @@ func (ls *LetStatement) String() string {
 	return out.String()
 }
 
+// Pattern : 구조 분해 할당의 좌변 혹은 match의 패턴
+type Pattern interface {
+	Node
+	patternNode()
+}
+
+// LiteralPattern : 값이 같아야 매칭되는 리터럴 패턴 ex) 1, -2.5, "a", true
+type LiteralPattern struct {
+	Value Expression
+}
+
+func (lp *LiteralPattern) patternNode()         {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Value.TokenLiteral() }
+func (lp *LiteralPattern) Pos() token.Position  { return lp.Value.Pos() }
+func (lp *LiteralPattern) End() token.Position  { return lp.Value.End() }
+func (lp *LiteralPattern) String() string       { return lp.Value.String() }
+
+// ArrayPattern : 배열 구조 분해 패턴 ex) [first, second, ...rest]
+type ArrayPattern struct {
+	Token    token.Token // '[' 토큰
+	Elements []Pattern
+	Rest     *Identifier // ...rest 로 받는 나머지 요소 (없으면 nil)
+	Rbrack   token.Token // ']' 토큰
+}
+
+func (ap *ArrayPattern) patternNode()         {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *ArrayPattern) Pos() token.Position  { return ap.Token.Pos }
+func (ap *ArrayPattern) End() token.Position  { return ap.Rbrack.End }
+func (ap *ArrayPattern) String() string {
+	names := []string{}
+	for _, el := range ap.Elements {
+		names = append(names, el.String())
+	}
+	if ap.Rest != nil {
+		names = append(names, "..."+ap.Rest.String())
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// HashPattern : 해시 구조 분해 패턴
+// ex) {name, age} -> 해시의 "name", "age" 키 값을 같은 이름의 변수에 바인딩
+// ex) {"kind": "circle", r: radius} -> "kind" 키의 값이 "circle"이면 "r" 키의 값을 radius에 바인딩
+type HashPattern struct {
+	Token  token.Token // '{' 토큰
+	Pairs  []*HashPatternPair
+	Rbrace token.Token // '}' 토큰
+}
+
+// HashPatternPair : 해시 패턴의 키와 그 값에 대한 패턴
+type HashPatternPair struct {
+	Key   Expression // 키 (식별자로 쓴 키는 같은 이름의 문자열 키)
+	Value Pattern
+}
+
+func (hpp *HashPatternPair) String() string {
+	// {name} 처럼 키와 변수명이 같은 축약형
+	if key, ok := hpp.Key.(*StringLiteral); ok && key.Token.Type == token.IDENT {
+		if value, ok := hpp.Value.(*Identifier); ok && value.Value == key.Value {
+			return key.Value
+		}
+	}
+	return hpp.Key.String() + ": " + hpp.Value.String()
+}
+
+func (hp *HashPattern) patternNode()         {}
+func (hp *HashPattern) TokenLiteral() string { return hp.Token.Literal }
+func (hp *HashPattern) Pos() token.Position  { return hp.Token.Pos }
+func (hp *HashPattern) End() token.Position  { return hp.Rbrace.End }
+func (hp *HashPattern) String() string {
+	pairs := []string{}
+	for _, pair := range hp.Pairs {
+		pairs = append(pairs, pair.String())
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
 // ReturnStatement : return 구문
 type ReturnStatement struct {
 	Token       token.Token // token.RETURN 토큰
@@ -88,6 +206,13 @@ type ReturnStatement struct {
 
 func (rs *ReturnStatement) statementNode()       {}
 func (rs *ReturnStatement) TokenLiteral() string { return rs.Token.Literal }
+func (rs *ReturnStatement) Pos() token.Position  { return rs.Token.Pos }
+func (rs *ReturnStatement) End() token.Position {
+	if rs.ReturnValue != nil {
+		return rs.ReturnValue.End()
+	}
+	return rs.Token.End
+}
 func (rs *ReturnStatement) String() string {
 	var out bytes.Buffer
 
@@ -102,6 +227,151 @@ func (rs *ReturnStatement) String() string {
 	return out.String()
 }
 
+// WhileStatement : while 반복문 ex) while (x < 10) { ... }
+type WhileStatement struct {
+	Token     token.Token // token.WHILE 토큰
+	Condition Expression
+	Body      *BlockStatement
+}
+
+func (ws *WhileStatement) statementNode()       {}
+func (ws *WhileStatement) TokenLiteral() string { return ws.Token.Literal }
+func (ws *WhileStatement) Pos() token.Position  { return ws.Token.Pos }
+func (ws *WhileStatement) End() token.Position {
+	if ws.Body != nil {
+		return ws.Body.End()
+	}
+	return ws.Token.End
+}
+func (ws *WhileStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("while")
+	out.WriteString(ws.Condition.String())
+	out.WriteString(" ")
+	out.WriteString(ws.Body.String())
+
+	return out.String()
+}
+
+// ForInStatement : for-in 반복문 ex) for (x in arr) { ... }, for (k, v in hash) { ... }
+type ForInStatement struct {
+	Token    token.Token // token.FOR 토큰
+	Key      *Identifier // 변수가 두 개일 때의 첫번째 변수 (인덱스 혹은 키), 하나면 nil
+	Value    *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+func (fs *ForInStatement) statementNode()       {}
+func (fs *ForInStatement) TokenLiteral() string { return fs.Token.Literal }
+func (fs *ForInStatement) Pos() token.Position  { return fs.Token.Pos }
+func (fs *ForInStatement) End() token.Position {
+	if fs.Body != nil {
+		return fs.Body.End()
+	}
+	return fs.Token.End
+}
+func (fs *ForInStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("for(")
+	if fs.Key != nil {
+		out.WriteString(fs.Key.String() + ", ")
+	}
+	out.WriteString(fs.Value.String())
+	out.WriteString(" in ")
+	out.WriteString(fs.Iterable.String())
+	out.WriteString(") ")
+	out.WriteString(fs.Body.String())
+
+	return out.String()
+}
+
+// ThrowStatement : 값을 에러로 던지는 구문 ex) throw error("boom", data);
+type ThrowStatement struct {
+	Token token.Token // token.THROW 토큰
+	Value Expression
+}
+
+func (ts *ThrowStatement) statementNode()       {}
+func (ts *ThrowStatement) TokenLiteral() string { return ts.Token.Literal }
+func (ts *ThrowStatement) Pos() token.Position  { return ts.Token.Pos }
+func (ts *ThrowStatement) End() token.Position {
+	if ts.Value != nil {
+		return ts.Value.End()
+	}
+	return ts.Token.End
+}
+func (ts *ThrowStatement) String() string {
+	return ts.TokenLiteral() + " " + ts.Value.String() + ";"
+}
+
+// ImportStatement : 모듈을 불러와서 이름에 바인딩 ex) import "lib/strings.mk" as s;
+type ImportStatement struct {
+	Token token.Token // token.IMPORT 토큰
+	Path  *StringLiteral
+	Alias *Identifier // as 로 붙인 이름 (생략하면 nil이고 파일명을 이름으로 씀)
+}
+
+func (is *ImportStatement) statementNode()       {}
+func (is *ImportStatement) TokenLiteral() string { return is.Token.Literal }
+func (is *ImportStatement) Pos() token.Position  { return is.Token.Pos }
+func (is *ImportStatement) End() token.Position {
+	if is.Alias != nil {
+		return is.Alias.End()
+	}
+	return is.Path.End()
+}
+func (is *ImportStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(is.TokenLiteral() + " \"" + is.Path.Value + "\"")
+	if is.Alias != nil {
+		out.WriteString(" as " + is.Alias.String())
+	}
+	out.WriteString(";")
+
+	return out.String()
+}
+
+// ExportStatement : 모듈 밖으로 내보낼 선언 ex) export let pi = 3.14; export fn upper(s) { ... }
+type ExportStatement struct {
+	Token       token.Token // token.EXPORT 토큰
+	Declaration Statement   // *LetStatement 혹은 *FunctionStatement
+	Name        *Identifier // 내보내는 이름
+}
+
+func (es *ExportStatement) statementNode()       {}
+func (es *ExportStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExportStatement) Pos() token.Position  { return es.Token.Pos }
+func (es *ExportStatement) End() token.Position  { return es.Declaration.End() }
+func (es *ExportStatement) String() string {
+	return es.TokenLiteral() + " " + es.Declaration.String()
+}
+
+// BreakStatement : 반복문을 빠져나가는 break 구문
+type BreakStatement struct {
+	Token token.Token // token.BREAK 토큰
+}
+
+func (bs *BreakStatement) statementNode()       {}
+func (bs *BreakStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BreakStatement) Pos() token.Position  { return bs.Token.Pos }
+func (bs *BreakStatement) End() token.Position  { return bs.Token.End }
+func (bs *BreakStatement) String() string       { return bs.Token.Literal + ";" }
+
+// ContinueStatement : 반복문의 다음 회차로 넘어가는 continue 구문
+type ContinueStatement struct {
+	Token token.Token // token.CONTINUE 토큰
+}
+
+func (cs *ContinueStatement) statementNode()       {}
+func (cs *ContinueStatement) TokenLiteral() string { return cs.Token.Literal }
+func (cs *ContinueStatement) Pos() token.Position  { return cs.Token.Pos }
+func (cs *ContinueStatement) End() token.Position  { return cs.Token.End }
+func (cs *ContinueStatement) String() string       { return cs.Token.Literal + ";" }
+
 // ExpressionStatement : 표현식 구문
 type ExpressionStatement struct {
 	Token      token.Token
@@ -110,6 +380,13 @@ type ExpressionStatement struct {
 
 func (es *ExpressionStatement) statementNode()       {}
 func (es *ExpressionStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExpressionStatement) Pos() token.Position  { return es.Token.Pos }
+func (es *ExpressionStatement) End() token.Position {
+	if es.Expression != nil {
+		return es.Expression.End()
+	}
+	return es.Token.End
+}
 func (es *ExpressionStatement) String() string {
 	if es.Expression != nil {
 		return es.Expression.String()
@@ -126,6 +403,20 @@ type IntegerLiteral struct {
 func (il *IntegerLiteral) expressionNode()      {}
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
 func (il *IntegerLiteral) String() string       { return il.Token.Literal }
+func (il *IntegerLiteral) Pos() token.Position  { return il.Token.Pos }
+func (il *IntegerLiteral) End() token.Position  { return il.Token.End }
+
+// FloatLiteral : 실수 리터럴
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode()      {}
+func (fl *FloatLiteral) TokenLiteral() string { return fl.Token.Literal }
+func (fl *FloatLiteral) String() string       { return fl.Token.Literal }
+func (fl *FloatLiteral) Pos() token.Position  { return fl.Token.Pos }
+func (fl *FloatLiteral) End() token.Position  { return fl.Token.End }
 
 // PrefixExpression : 전위 표현식
 type PrefixExpression struct {
@@ -136,6 +427,13 @@ type PrefixExpression struct {
 
 func (pe *PrefixExpression) expressionNode()      {}
 func (pe *PrefixExpression) TokenLiteral() string { return pe.Token.Literal }
+func (pe *PrefixExpression) Pos() token.Position  { return pe.Token.Pos }
+func (pe *PrefixExpression) End() token.Position {
+	if pe.Right != nil {
+		return pe.Right.End()
+	}
+	return pe.Token.End
+}
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
 
@@ -157,6 +455,21 @@ type InfixExpression struct {
 
 func (ie *InfixExpression) expressionNode()      {}
 func (ie *InfixExpression) TokenLiteral() string { return ie.Token.Literal }
+
+// 중위 표현식은 연산자가 아니라 좌측 피연산자부터 시작함
+func (ie *InfixExpression) Pos() token.Position {
+	if ie.Left != nil {
+		return ie.Left.Pos()
+	}
+	return ie.Token.Pos
+}
+
+func (ie *InfixExpression) End() token.Position {
+	if ie.Right != nil {
+		return ie.Right.End()
+	}
+	return ie.Token.End
+}
 func (ie *InfixExpression) String() string {
 	var out bytes.Buffer
 
@@ -169,6 +482,43 @@ func (ie *InfixExpression) String() string {
 	return out.String()
 }
 
+// AssignExpression : 대입 표현식 ex) x = 5, x += 1
+type AssignExpression struct {
+	Token    token.Token // =, +=, -=, *=, /= 토큰
+	Name     *Identifier
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) expressionNode()      {}
+func (ae *AssignExpression) TokenLiteral() string { return ae.Token.Literal }
+
+// 대입 표현식은 연산자가 아니라 대입할 변수 이름부터 시작함
+func (ae *AssignExpression) Pos() token.Position {
+	if ae.Name != nil {
+		return ae.Name.Pos()
+	}
+	return ae.Token.Pos
+}
+
+func (ae *AssignExpression) End() token.Position {
+	if ae.Value != nil {
+		return ae.Value.End()
+	}
+	return ae.Token.End
+}
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ae.Name.String())
+	out.WriteString(" " + ae.Operator + " ")
+	out.WriteString(ae.Value.String())
+	out.WriteString(")")
+
+	return out.String()
+}
+
 type Boolean struct {
 	Token token.Token
 	Value bool
@@ -177,6 +527,8 @@ type Boolean struct {
 func (b *Boolean) expressionNode()      {}
 func (b *Boolean) TokenLiteral() string { return b.Token.Literal }
 func (b *Boolean) String() string       { return b.Token.Literal }
+func (b *Boolean) Pos() token.Position  { return b.Token.Pos }
+func (b *Boolean) End() token.Position  { return b.Token.End }
 
 // IfExpression : if 표현식
 type IfExpression struct {
@@ -188,6 +540,16 @@ type IfExpression struct {
 
 func (ie *IfExpression) expressionNode()      {}
 func (ie *IfExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *IfExpression) Pos() token.Position  { return ie.Token.Pos }
+func (ie *IfExpression) End() token.Position {
+	if ie.Alternative != nil {
+		return ie.Alternative.End()
+	}
+	if ie.Consequence != nil {
+		return ie.Consequence.End()
+	}
+	return ie.Token.End
+}
 func (ie *IfExpression) String() string {
 
 	var out bytes.Buffer
@@ -205,14 +567,69 @@ func (ie *IfExpression) String() string {
 	return out.String()
 }
 
+// TryExpression : try 표현식 ex) try { ... } catch (e) { ... } finally { ... }
+// catch 와 finally 중 하나는 생략할 수 있음
+type TryExpression struct {
+	Token      token.Token // token.TRY 토큰
+	Block      *BlockStatement
+	CatchParam *Identifier     // catch (e) 의 e (catch 가 없거나 파라미터가 없으면 nil)
+	Catch      *BlockStatement // catch 블록 (없으면 nil)
+	Finally    *BlockStatement // finally 블록 (없으면 nil)
+}
+
+func (te *TryExpression) expressionNode()      {}
+func (te *TryExpression) TokenLiteral() string { return te.Token.Literal }
+func (te *TryExpression) Pos() token.Position  { return te.Token.Pos }
+func (te *TryExpression) End() token.Position {
+	switch {
+	case te.Finally != nil:
+		return te.Finally.End()
+	case te.Catch != nil:
+		return te.Catch.End()
+	case te.Block != nil:
+		return te.Block.End()
+	}
+	return te.Token.End
+}
+func (te *TryExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("try ")
+	out.WriteString(te.Block.String())
+	if te.Catch != nil {
+		out.WriteString(" catch")
+		if te.CatchParam != nil {
+			out.WriteString("(" + te.CatchParam.String() + ")")
+		}
+		out.WriteString(" ")
+		out.WriteString(te.Catch.String())
+	}
+	if te.Finally != nil {
+		out.WriteString(" finally ")
+		out.WriteString(te.Finally.String())
+	}
+
+	return out.String()
+}
+
 // BlockStatement : 블록 구문
 type BlockStatement struct {
-	Token      token.Token
+	Token      token.Token // '{' 토큰
 	Statements []Statement
+	Rbrace     token.Token // '}' 토큰
 }
 
 func (bs *BlockStatement) expressionNode()      {}
 func (bs *BlockStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BlockStatement) Pos() token.Position  { return bs.Token.Pos }
+
+// 람다의 본문처럼 중괄호 없이 만들어진 블록은 마지막 구문에서 끝남
+func (bs *BlockStatement) End() token.Position {
+	if bs.Rbrace.Type == "" && len(bs.Statements) > 0 {
+		return bs.Statements[len(bs.Statements)-1].End()
+	}
+	return bs.Rbrace.End
+}
 func (bs *BlockStatement) String() string {
 
 	var out bytes.Buffer
@@ -227,26 +644,107 @@ func (bs *BlockStatement) String() string {
 // FunctionLiteral : 함수 리터럴
 type FunctionLiteral struct {
 	Token      token.Token // 'fn' 토큰
+	Name       string      // 함수 이름 (선언문 혹은 let으로 바인딩한 경우, 익명 함수면 빈 문자열)
 	Parameters []*Identifier
+	Defaults   map[string]Expression // 파라미터명별 기본값 ex) fn(a, b = 10) -> {"b": 10}
+	Rest       *Identifier           // 나머지 인자를 배열로 받는 가변 파라미터 ex) fn(first, ...rest) (없으면 nil)
 	Body       *BlockStatement
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
 func (fl *FunctionLiteral) TokenLiteral() string { return fl.Token.Literal }
+func (fl *FunctionLiteral) Pos() token.Position  { return fl.Token.Pos }
+func (fl *FunctionLiteral) End() token.Position {
+	if fl.Body != nil {
+		return fl.Body.End()
+	}
+	return fl.Token.End
+}
 func (fl *FunctionLiteral) String() string {
 
 	var out bytes.Buffer
 
-	params := []string{}
-	for _, p := range fl.Parameters {
-		params = append(params, p.String())
+	// 람다(|x| x * 2)도 같은 함수 리터럴이므로 fn 형태로 출력
+	out.WriteString("fn")
+	out.WriteString("(")
+	out.WriteString(FormatParameters(fl.Parameters, fl.Defaults, fl.Rest))
+	out.WriteString(") ")
+	out.WriteString(fl.Body.String())
+
+	return out.String()
+}
+
+// FormatParameters : 파라미터 목록을 소스 형태로 출력 ex) a, b = 10, ...rest
+func FormatParameters(params []*Identifier, defaults map[string]Expression, rest *Identifier) string {
+	list := []string{}
+	for _, p := range params {
+		if def, ok := defaults[p.Value]; ok {
+			list = append(list, p.String()+" = "+def.String())
+		} else {
+			list = append(list, p.String())
+		}
+	}
+	if rest != nil {
+		list = append(list, "..."+rest.String())
 	}
+	return strings.Join(list, ", ")
+}
 
-	out.WriteString(fl.String())
+// FunctionStatement : 함수 선언문 ex) fn add(a, b) { a + b }
+// 선언된 블록의 다른 구문보다 먼저 바인딩됨 (호이스팅)
+type FunctionStatement struct {
+	Token    token.Token // 'fn' 토큰
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+func (fs *FunctionStatement) statementNode()       {}
+func (fs *FunctionStatement) TokenLiteral() string { return fs.Token.Literal }
+func (fs *FunctionStatement) Pos() token.Position  { return fs.Token.Pos }
+func (fs *FunctionStatement) End() token.Position {
+	if fs.Function != nil {
+		return fs.Function.End()
+	}
+	return fs.Token.End
+}
+func (fs *FunctionStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(fs.TokenLiteral() + " ")
+	out.WriteString(fs.Name.String())
 	out.WriteString("(")
-	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(FormatParameters(fs.Function.Parameters, fs.Function.Defaults, fs.Function.Rest))
 	out.WriteString(") ")
-	out.WriteString(fl.Body.String())
+	out.WriteString(fs.Function.Body.String())
+
+	return out.String()
+}
+
+// MacroLiteral : 매크로 리터럴 ex) let unless = macro(cond, body) { quote(if (!(unquote(cond))) { unquote(body) }) };
+// 인자를 평가하지 않고 AST 그대로 받아서, 실행 전에 호출한 자리를 매크로가 리턴한 AST로 바꿈
+type MacroLiteral struct {
+	Token      token.Token // 'macro' 토큰
+	Parameters []*Identifier
+	Body       *BlockStatement
+}
+
+func (ml *MacroLiteral) expressionNode()      {}
+func (ml *MacroLiteral) TokenLiteral() string { return ml.Token.Literal }
+func (ml *MacroLiteral) Pos() token.Position  { return ml.Token.Pos }
+func (ml *MacroLiteral) End() token.Position {
+	if ml.Body != nil {
+		return ml.Body.End()
+	}
+	return ml.Token.End
+}
+func (ml *MacroLiteral) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ml.TokenLiteral())
+	out.WriteString("(")
+	out.WriteString(FormatParameters(ml.Parameters, nil, nil))
+	out.WriteString(") ")
+	out.WriteString(ml.Body.String())
 
 	return out.String()
 }
@@ -255,10 +753,25 @@ type CallExpression struct {
 	Token     token.Token // 여는 괄호 토큰 '('
 	Function  Expression  // 식별자(=함수명) 혹은 함수 리터럴(즉시 실행 함수일 경우)
 	Arguments []Expression
+	Rparen    token.Token // 닫는 괄호 토큰 ')'
 }
 
 func (ce *CallExpression) expressionNode()      {}
 func (ce *CallExpression) TokenLiteral() string { return ce.Token.Literal }
+
+// |> 로 만들어진 호출식은 닫는 괄호가 없을 수 있음 ex) xs |> sum
+func (ce *CallExpression) End() token.Position {
+	if ce.Rparen.Type == "" && ce.Function != nil {
+		return ce.Function.End()
+	}
+	return ce.Rparen.End
+}
+func (ce *CallExpression) Pos() token.Position {
+	if ce.Function != nil {
+		return ce.Function.Pos()
+	}
+	return ce.Token.Pos
+}
 func (ce *CallExpression) String() string {
 
 	var out bytes.Buffer
@@ -283,15 +796,59 @@ type StringLiteral struct {
 
 func (sl *StringLiteral) expressionNode()      {}
 func (sl *StringLiteral) TokenLiteral() string { return sl.Token.Literal }
-func (sl *StringLiteral) String() string       { return sl.Token.Literal }
+
+// 백틱 문자열은 소스와 같은 형태로 출력
+func (sl *StringLiteral) String() string {
+	if sl.Token.Type == token.RAW_STRING {
+		return "`" + sl.Value + "`"
+	}
+	return sl.Token.Literal
+}
+
+func (sl *StringLiteral) Pos() token.Position { return sl.Token.Pos }
+func (sl *StringLiteral) End() token.Position { return sl.Token.End }
+
+// InterpolatedString : 보간식이 포함된 문자열 ex) "Hello ${name}!"
+type InterpolatedString struct {
+	Token token.Token  // token.INTERP_HEAD 토큰
+	Parts []Expression // 문자열 조각(*StringLiteral)과 보간식이 순서대로 들어있음
+}
+
+func (is *InterpolatedString) expressionNode()      {}
+func (is *InterpolatedString) TokenLiteral() string { return is.Token.Literal }
+func (is *InterpolatedString) Pos() token.Position  { return is.Token.Pos }
+func (is *InterpolatedString) End() token.Position {
+	if len(is.Parts) == 0 {
+		return is.Token.End
+	}
+	return is.Parts[len(is.Parts)-1].End()
+}
+func (is *InterpolatedString) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("\"")
+	for _, part := range is.Parts {
+		if literal, ok := part.(*StringLiteral); ok {
+			out.WriteString(literal.Value)
+		} else {
+			out.WriteString("${" + part.String() + "}")
+		}
+	}
+	out.WriteString("\"")
+
+	return out.String()
+}
 
 type ArrayLiteral struct {
-	Token    token.Token
+	Token    token.Token // '[' 토큰
 	Elements []Expression
+	Rbrack   token.Token // ']' 토큰
 }
 
 func (al *ArrayLiteral) expressionNode()      {}
 func (al *ArrayLiteral) TokenLiteral() string { return al.Token.Literal }
+func (al *ArrayLiteral) Pos() token.Position  { return al.Token.Pos }
+func (al *ArrayLiteral) End() token.Position  { return al.Rbrack.End }
 func (al *ArrayLiteral) String() string {
 	var out bytes.Buffer
 
@@ -306,13 +863,21 @@ func (al *ArrayLiteral) String() string {
 }
 
 type IndexExpression struct {
-	Token token.Token
-	Left  Expression
-	Index Expression
+	Token  token.Token // '[' 토큰
+	Left   Expression
+	Index  Expression
+	Rbrack token.Token // ']' 토큰
 }
 
 func (ie *IndexExpression) expressionNode()      {}
 func (ie *IndexExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *IndexExpression) End() token.Position  { return ie.Rbrack.End }
+func (ie *IndexExpression) Pos() token.Position {
+	if ie.Left != nil {
+		return ie.Left.Pos()
+	}
+	return ie.Token.Pos
+}
 func (ie *IndexExpression) String() string {
 	var out bytes.Buffer
 
@@ -325,13 +890,112 @@ func (ie *IndexExpression) String() string {
 	return out.String()
 }
 
+// SliceExpression : 슬라이스 표현식 ex) a[1:3], a[:n], s[2:]
+type SliceExpression struct {
+	Token  token.Token // '[' 토큰
+	Left   Expression
+	Start  Expression  // 생략하면 nil (처음부터)
+	Stop   Expression  // 생략하면 nil (끝까지)
+	Rbrack token.Token // ']' 토큰
+}
+
+func (se *SliceExpression) expressionNode()      {}
+func (se *SliceExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SliceExpression) End() token.Position  { return se.Rbrack.End }
+func (se *SliceExpression) Pos() token.Position {
+	if se.Left != nil {
+		return se.Left.Pos()
+	}
+	return se.Token.Pos
+}
+func (se *SliceExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(se.Left.String())
+	out.WriteString("[")
+	if se.Start != nil {
+		out.WriteString(se.Start.String())
+	}
+	out.WriteString(":")
+	if se.Stop != nil {
+		out.WriteString(se.Stop.String())
+	}
+	out.WriteString("])")
+
+	return out.String()
+}
+
+// MemberExpression : 모듈이 내보낸 이름에 접근 ex) strings.upper
+type MemberExpression struct {
+	Token    token.Token // '.' 토큰
+	Object   Expression
+	Property *Identifier
+}
+
+func (me *MemberExpression) expressionNode()      {}
+func (me *MemberExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MemberExpression) End() token.Position  { return me.Property.End() }
+func (me *MemberExpression) Pos() token.Position {
+	if me.Object != nil {
+		return me.Object.Pos()
+	}
+	return me.Token.Pos
+}
+func (me *MemberExpression) String() string {
+	return "(" + me.Object.String() + "." + me.Property.String() + ")"
+}
+
+// MatchExpression : 패턴 매칭 표현식 ex) match (x) { 0 => "zero", n if n < 0 => "negative", _ => "positive" }
+type MatchExpression struct {
+	Token   token.Token // token.MATCH 토큰
+	Subject Expression
+	Arms    []*MatchArm
+	Rbrace  token.Token // '}' 토큰
+}
+
+// MatchArm : match의 갈래 하나 (pattern if guard => body)
+type MatchArm struct {
+	Pattern Pattern
+	Guard   Expression // 없으면 nil
+	Body    Expression
+}
+
+func (ma *MatchArm) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ma.Pattern.String())
+	if ma.Guard != nil {
+		out.WriteString(" if " + ma.Guard.String())
+	}
+	out.WriteString(" => ")
+	out.WriteString(ma.Body.String())
+
+	return out.String()
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MatchExpression) Pos() token.Position  { return me.Token.Pos }
+func (me *MatchExpression) End() token.Position  { return me.Rbrace.End }
+func (me *MatchExpression) String() string {
+	arms := []string{}
+	for _, arm := range me.Arms {
+		arms = append(arms, arm.String())
+	}
+	return "match(" + me.Subject.String() + ") { " + strings.Join(arms, ", ") + " }"
+}
+
 type HashLiteral struct {
-	Token token.Token // '{' 토큰
-	Pairs map[Expression]Expression
+	Token  token.Token // '{' 토큰
+	Pairs  map[Expression]Expression
+	Rbrace token.Token // '}' 토큰
 }
 
 func (hl *HashLiteral) expressionNode()      {}
 func (hl *HashLiteral) TokenLiteral() string { return hl.Token.Literal }
+func (hl *HashLiteral) Pos() token.Position  { return hl.Token.Pos }
+func (hl *HashLiteral) End() token.Position  { return hl.Rbrace.End }
 func (hl *HashLiteral) String() string {
 	var out bytes.Buffer
 
@@ -346,3 +1010,46 @@ func (hl *HashLiteral) String() string {
 
 	return out.String()
 }
+
+// Comment : 주석 하나 (// 혹은 /* */)
+type Comment struct {
+	Token token.Token // token.COMMENT 토큰
+}
+
+func (c *Comment) TokenLiteral() string { return c.Token.Literal }
+func (c *Comment) String() string       { return c.Token.Literal }
+func (c *Comment) Pos() token.Position  { return c.Token.Pos }
+func (c *Comment) End() token.Position  { return c.Token.End }
+
+// CommentGroup : 빈 줄 없이 연달아 나오는 주석 묶음
+type CommentGroup struct {
+	List []*Comment
+}
+
+func (g *CommentGroup) TokenLiteral() string { return g.List[0].TokenLiteral() }
+func (g *CommentGroup) Pos() token.Position  { return g.List[0].Pos() }
+func (g *CommentGroup) End() token.Position  { return g.List[len(g.List)-1].End() }
+func (g *CommentGroup) String() string {
+	comments := []string{}
+	for _, c := range g.List {
+		comments = append(comments, c.String())
+	}
+	return strings.Join(comments, "\n")
+}
+
+// Text : 주석 기호(//, /*, */)를 뗀 본문
+func (g *CommentGroup) Text() string {
+	lines := []string{}
+	for _, c := range g.List {
+		text := c.Token.Literal
+		if strings.HasPrefix(text, "//") {
+			text = text[2:]
+		} else {
+			text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+		}
+		for _, line := range strings.Split(text, "\n") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,228 @@
+package ast
+
+// Copy : Modify 가 방문하는 노드들을 새로 만들어서 리턴 (원래 트리는 바뀌지 않음)
+// Modify 는 트리를 제자리에서 바꾸므로 여러 번 쓰이는 트리(매크로, 함수 본문의 quote 등)는 복사한 후 바꿔야 함
+// Modify 가 방문하지 않는 패턴과 이름(변수명, 파라미터 등)은 복사하지 않고 공유
+func Copy(node Node) Node {
+	switch node := node.(type) {
+	case *Program:
+		copied := *node
+		copied.Statements = copyStatements(node.Statements)
+		return &copied
+
+	case *ExpressionStatement:
+		copied := *node
+		copied.Expression = copyExpression(node.Expression)
+		return &copied
+
+	case *BlockStatement:
+		return copyBlock(node)
+
+	case *LetStatement:
+		copied := *node
+		copied.Value = copyExpression(node.Value)
+		return &copied
+
+	case *ReturnStatement:
+		copied := *node
+		copied.ReturnValue = copyExpression(node.ReturnValue)
+		return &copied
+
+	case *ThrowStatement:
+		copied := *node
+		copied.Value = copyExpression(node.Value)
+		return &copied
+
+	case *WhileStatement:
+		copied := *node
+		copied.Condition = copyExpression(node.Condition)
+		copied.Body = copyBlock(node.Body)
+		return &copied
+
+	case *ForInStatement:
+		copied := *node
+		copied.Iterable = copyExpression(node.Iterable)
+		copied.Body = copyBlock(node.Body)
+		return &copied
+
+	case *ExportStatement:
+		copied := *node
+		copied.Declaration, _ = Copy(node.Declaration).(Statement)
+		return &copied
+
+	case *FunctionStatement:
+		copied := *node
+		copied.Function, _ = Copy(node.Function).(*FunctionLiteral)
+		return &copied
+
+	case *ImportStatement:
+		copied := *node
+		return &copied
+
+	case *BreakStatement:
+		copied := *node
+		return &copied
+
+	case *ContinueStatement:
+		copied := *node
+		return &copied
+
+	case *Identifier:
+		copied := *node
+		return &copied
+
+	case *IntegerLiteral:
+		copied := *node
+		return &copied
+
+	case *FloatLiteral:
+		copied := *node
+		return &copied
+
+	case *Boolean:
+		copied := *node
+		return &copied
+
+	case *StringLiteral:
+		copied := *node
+		return &copied
+
+	case *MacroLiteral:
+		copied := *node
+		return &copied
+
+	case *PrefixExpression:
+		copied := *node
+		copied.Right = copyExpression(node.Right)
+		return &copied
+
+	case *InfixExpression:
+		copied := *node
+		copied.Left = copyExpression(node.Left)
+		copied.Right = copyExpression(node.Right)
+		return &copied
+
+	case *AssignExpression:
+		copied := *node
+		copied.Value = copyExpression(node.Value)
+		return &copied
+
+	case *IfExpression:
+		copied := *node
+		copied.Condition = copyExpression(node.Condition)
+		copied.Consequence = copyBlock(node.Consequence)
+		copied.Alternative = copyBlock(node.Alternative)
+		return &copied
+
+	case *TryExpression:
+		copied := *node
+		copied.Block = copyBlock(node.Block)
+		copied.Catch = copyBlock(node.Catch)
+		copied.Finally = copyBlock(node.Finally)
+		return &copied
+
+	case *FunctionLiteral:
+		copied := *node
+		if node.Defaults != nil {
+			copied.Defaults = make(map[string]Expression, len(node.Defaults))
+			for name, def := range node.Defaults {
+				copied.Defaults[name] = copyExpression(def)
+			}
+		}
+		copied.Body = copyBlock(node.Body)
+		return &copied
+
+	case *CallExpression:
+		copied := *node
+		copied.Function = copyExpression(node.Function)
+		copied.Arguments = copyExpressions(node.Arguments)
+		return &copied
+
+	case *InterpolatedString:
+		copied := *node
+		copied.Parts = copyExpressions(node.Parts)
+		return &copied
+
+	case *ArrayLiteral:
+		copied := *node
+		copied.Elements = copyExpressions(node.Elements)
+		return &copied
+
+	case *IndexExpression:
+		copied := *node
+		copied.Left = copyExpression(node.Left)
+		copied.Index = copyExpression(node.Index)
+		return &copied
+
+	case *MemberExpression:
+		copied := *node
+		copied.Object = copyExpression(node.Object)
+		return &copied
+
+	case *SliceExpression:
+		copied := *node
+		copied.Left = copyExpression(node.Left)
+		copied.Start = copyExpression(node.Start)
+		copied.Stop = copyExpression(node.Stop)
+		return &copied
+
+	case *MatchExpression:
+		copied := *node
+		copied.Subject = copyExpression(node.Subject)
+		copied.Arms = make([]*MatchArm, len(node.Arms))
+		for i, arm := range node.Arms {
+			copiedArm := *arm
+			copiedArm.Guard = copyExpression(arm.Guard)
+			copiedArm.Body = copyExpression(arm.Body)
+			copied.Arms[i] = &copiedArm
+		}
+		return &copied
+
+	case *HashLiteral:
+		copied := *node
+		copied.Pairs = make(map[Expression]Expression, len(node.Pairs))
+		for key, value := range node.Pairs {
+			copied.Pairs[copyExpression(key)] = copyExpression(value)
+		}
+		return &copied
+	}
+
+	return node
+}
+
+func copyExpression(expression Expression) Expression {
+	copied, _ := Copy(expression).(Expression)
+	return copied
+}
+
+func copyExpressions(expressions []Expression) []Expression {
+	if expressions == nil {
+		return nil
+	}
+	copied := make([]Expression, len(expressions))
+	for i, expression := range expressions {
+		copied[i] = copyExpression(expression)
+	}
+	return copied
+}
+
+func copyStatements(statements []Statement) []Statement {
+	if statements == nil {
+		return nil
+	}
+	copied := make([]Statement, len(statements))
+	for i, statement := range statements {
+		copied[i], _ = Copy(statement).(Statement)
+	}
+	return copied
+}
+
+// 생략된 블록(nil)은 그대로 nil
+func copyBlock(block *BlockStatement) *BlockStatement {
+	if block == nil {
+		return nil
+	}
+	copied := *block
+	copied.Statements = copyStatements(block.Statements)
+	return &copied
+}
@@ -0,0 +1,78 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCopy(t *testing.T) {
+	one := func() Expression { return &IntegerLiteral{Value: 1} }
+	block := func() *BlockStatement {
+		return &BlockStatement{Statements: []Statement{&ExpressionStatement{Expression: one()}}}
+	}
+
+	// 모든 1 을 제자리에서 2 로 바꿈
+	turnOneIntoTwo := func(node Node) Node {
+		if integer, ok := node.(*IntegerLiteral); ok && integer.Value == 1 {
+			integer.Value = 2
+		}
+		return node
+	}
+
+	// 같은 트리를 두 번 만들어서 하나는 복사 후 바꾸고, 다른 하나와 비교
+	tests := []func() Node{
+		func() Node { return one() },
+		func() Node { return &Program{Statements: []Statement{&ExpressionStatement{Expression: one()}}} },
+		func() Node { return &InfixExpression{Left: one(), Operator: "+", Right: one()} },
+		func() Node { return &PrefixExpression{Operator: "-", Right: one()} },
+		func() Node { return &IndexExpression{Left: one(), Index: one()} },
+		func() Node { return &SliceExpression{Left: one(), Stop: one()} },
+		func() Node { return &MemberExpression{Object: one(), Property: &Identifier{Value: "x"}} },
+		func() Node { return &IfExpression{Condition: one(), Consequence: block(), Alternative: block()} },
+		func() Node { return &TryExpression{Block: block(), Catch: block(), Finally: block()} },
+		func() Node { return &ReturnStatement{ReturnValue: one()} },
+		func() Node { return &ThrowStatement{Value: one()} },
+		func() Node { return &LetStatement{Name: &Identifier{Value: "x"}, Value: one()} },
+		func() Node { return &WhileStatement{Condition: one(), Body: block()} },
+		func() Node { return &ForInStatement{Value: &Identifier{Value: "x"}, Iterable: one(), Body: block()} },
+		func() Node {
+			return &FunctionStatement{
+				Name:     &Identifier{Value: "f"},
+				Function: &FunctionLiteral{Defaults: map[string]Expression{"a": one()}, Body: block()},
+			}
+		},
+		func() Node { return &ExportStatement{Declaration: &LetStatement{Value: one()}} },
+		func() Node { return &CallExpression{Function: one(), Arguments: []Expression{one(), one()}} },
+		func() Node { return &InterpolatedString{Parts: []Expression{&StringLiteral{Value: "a"}, one()}} },
+		func() Node { return &ArrayLiteral{Elements: []Expression{one(), one()}} },
+		func() Node { return &AssignExpression{Name: &Identifier{Value: "x"}, Operator: "=", Value: one()} },
+		func() Node {
+			return &MatchExpression{Subject: one(), Arms: []*MatchArm{{Pattern: &Identifier{Value: "x"}, Guard: one(), Body: one()}}}
+		},
+	}
+
+	for i, tt := range tests {
+		original := tt()
+		copied := Copy(original)
+
+		if !reflect.DeepEqual(copied, tt()) {
+			t.Errorf("tests[%d] - copy not equal. got=%#v", i, copied)
+		}
+
+		Modify(copied, turnOneIntoTwo)
+
+		if !reflect.DeepEqual(original, tt()) {
+			t.Errorf("tests[%d] - original changed. got=%#v", i, original)
+		}
+	}
+
+	// 해시는 키와 값 모두 복사됨
+	hashLiteral := &HashLiteral{Pairs: map[Expression]Expression{one(): one()}}
+	Modify(Copy(hashLiteral), turnOneIntoTwo)
+
+	for key, val := range hashLiteral.Pairs {
+		if key.(*IntegerLiteral).Value != 1 || val.(*IntegerLiteral).Value != 1 {
+			t.Errorf("original hash changed. got=%v: %v", key, val)
+		}
+	}
+}
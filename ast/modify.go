@@ -0,0 +1,131 @@
+package ast
+
+// ModifierFunc : 노드를 받아서 바꿀 노드를 리턴 (바꾸지 않으면 받은 노드 그대로 리턴)
+type ModifierFunc func(Node) Node
+
+// Modify : 자식 노드부터 차례로 modifier를 적용한 후 자기 자신에게 적용 (트리를 제자리에서 바꿈)
+// 패턴은 값이 아니므로 바꾸지 않음
+func Modify(node Node, modifier ModifierFunc) Node {
+	switch node := node.(type) {
+	case *Program:
+		for i, statement := range node.Statements {
+			node.Statements[i], _ = Modify(statement, modifier).(Statement)
+		}
+
+	case *ExpressionStatement:
+		node.Expression, _ = Modify(node.Expression, modifier).(Expression)
+
+	case *BlockStatement:
+		for i, statement := range node.Statements {
+			node.Statements[i], _ = Modify(statement, modifier).(Statement)
+		}
+
+	case *LetStatement:
+		node.Value, _ = Modify(node.Value, modifier).(Expression)
+
+	case *ReturnStatement:
+		node.ReturnValue, _ = Modify(node.ReturnValue, modifier).(Expression)
+
+	case *ThrowStatement:
+		node.Value, _ = Modify(node.Value, modifier).(Expression)
+
+	case *WhileStatement:
+		node.Condition, _ = Modify(node.Condition, modifier).(Expression)
+		node.Body, _ = Modify(node.Body, modifier).(*BlockStatement)
+
+	case *ForInStatement:
+		node.Iterable, _ = Modify(node.Iterable, modifier).(Expression)
+		node.Body, _ = Modify(node.Body, modifier).(*BlockStatement)
+
+	case *ExportStatement:
+		node.Declaration, _ = Modify(node.Declaration, modifier).(Statement)
+
+	case *FunctionStatement:
+		node.Function, _ = Modify(node.Function, modifier).(*FunctionLiteral)
+
+	case *PrefixExpression:
+		node.Right, _ = Modify(node.Right, modifier).(Expression)
+
+	case *InfixExpression:
+		node.Left, _ = Modify(node.Left, modifier).(Expression)
+		node.Right, _ = Modify(node.Right, modifier).(Expression)
+
+	case *AssignExpression:
+		node.Value, _ = Modify(node.Value, modifier).(Expression)
+
+	case *IfExpression:
+		node.Condition, _ = Modify(node.Condition, modifier).(Expression)
+		node.Consequence, _ = Modify(node.Consequence, modifier).(*BlockStatement)
+		if node.Alternative != nil {
+			node.Alternative, _ = Modify(node.Alternative, modifier).(*BlockStatement)
+		}
+
+	case *TryExpression:
+		node.Block, _ = Modify(node.Block, modifier).(*BlockStatement)
+		if node.Catch != nil {
+			node.Catch, _ = Modify(node.Catch, modifier).(*BlockStatement)
+		}
+		if node.Finally != nil {
+			node.Finally, _ = Modify(node.Finally, modifier).(*BlockStatement)
+		}
+
+	case *FunctionLiteral:
+		for name, def := range node.Defaults {
+			node.Defaults[name], _ = Modify(def, modifier).(Expression)
+		}
+		node.Body, _ = Modify(node.Body, modifier).(*BlockStatement)
+
+	case *CallExpression:
+		node.Function, _ = Modify(node.Function, modifier).(Expression)
+		for i, argument := range node.Arguments {
+			node.Arguments[i], _ = Modify(argument, modifier).(Expression)
+		}
+
+	case *InterpolatedString:
+		for i, part := range node.Parts {
+			node.Parts[i], _ = Modify(part, modifier).(Expression)
+		}
+
+	case *ArrayLiteral:
+		for i, element := range node.Elements {
+			node.Elements[i], _ = Modify(element, modifier).(Expression)
+		}
+
+	case *IndexExpression:
+		node.Left, _ = Modify(node.Left, modifier).(Expression)
+		node.Index, _ = Modify(node.Index, modifier).(Expression)
+
+	case *MemberExpression:
+		node.Object, _ = Modify(node.Object, modifier).(Expression)
+
+	case *SliceExpression:
+		node.Left, _ = Modify(node.Left, modifier).(Expression)
+		if node.Start != nil {
+			node.Start, _ = Modify(node.Start, modifier).(Expression)
+		}
+		if node.Stop != nil {
+			node.Stop, _ = Modify(node.Stop, modifier).(Expression)
+		}
+
+	case *MatchExpression:
+		node.Subject, _ = Modify(node.Subject, modifier).(Expression)
+		for _, arm := range node.Arms {
+			if arm.Guard != nil {
+				arm.Guard, _ = Modify(arm.Guard, modifier).(Expression)
+			}
+			arm.Body, _ = Modify(arm.Body, modifier).(Expression)
+		}
+
+	case *HashLiteral:
+		// 키가 바뀔 수 있으므로 맵을 새로 만듦
+		pairs := make(map[Expression]Expression)
+		for key, value := range node.Pairs {
+			newKey, _ := Modify(key, modifier).(Expression)
+			newValue, _ := Modify(value, modifier).(Expression)
+			pairs[newKey] = newValue
+		}
+		node.Pairs = pairs
+	}
+
+	return modifier(node)
+}
@@ -3,10 +3,11 @@ package evaluator
 import (
 	"fmt"
 	"interpreter-go/object"
+	"unicode/utf8"
 )
 
 var builtins = map[string]*object.Builtin{
-	// 문자열 또는 배열의 길이를 리턴
+	// 문자열(문자 개수) 또는 배열의 길이를 리턴
 	"len": &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			if len(args) != 1 {
@@ -17,7 +18,7 @@ var builtins = map[string]*object.Builtin{
 			case *object.Array:
 				return &object.Integer{Value: int64(len(arg.Elements))}
 			case *object.String:
-				return &object.Integer{Value: int64(len(arg.Value))}
+				return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
 			default:
 				return newError("argument to len not supported, got %s", args[0].Type())
 			}
@@ -100,6 +101,72 @@ var builtins = map[string]*object.Builtin{
 			return &object.Array{Elements: newElements}
 		},
 	},
+	// start 이상 end 미만의 정수 배열 리턴 ex) range(3) -> [0, 1, 2], range(1, 10, 3) -> [1, 4, 7]
+	"range": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=1..3", len(args))
+			}
+
+			bounds := []int64{}
+			for _, arg := range args {
+				integer, ok := arg.(*object.Integer)
+				if !ok {
+					return newError("argument to range must be INTEGER, got %s", arg.Type())
+				}
+				bounds = append(bounds, integer.Value)
+			}
+
+			start, end, step := int64(0), bounds[0], int64(1)
+			if len(bounds) > 1 {
+				start, end = bounds[0], bounds[1]
+			}
+			if len(bounds) > 2 {
+				step = bounds[2]
+			}
+			if step == 0 {
+				return newError("range step must not be zero")
+			}
+
+			elements := []object.Object{}
+			for i := start; (step > 0 && i < end) || (step < 0 && i > end); i += step {
+				elements = append(elements, &object.Integer{Value: i})
+			}
+
+			return &object.Array{Elements: elements}
+		},
+	},
+	// error(msg) 혹은 error(msg, data) : throw 로 던질 에러 값을 만듦
+	"error": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1..2", len(args))
+			}
+
+			message, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument to error must be STRING, got %s", args[0].Type())
+			}
+
+			errorValue := &object.ErrorValue{Message: message.Value, Kind: USER_ERROR}
+			if len(args) == 2 {
+				errorValue.Data = args[1]
+			}
+			return errorValue
+		},
+	},
+	// freeze(value) : 배열과 해시를 안쪽까지 모두 얼린 것으로 표시하고 그 값을 그대로 리턴
+	// 지금은 배열과 해시를 제자리에서 바꾸는 연산이 없어서 (push 등은 새 값을 만듦) 모든 값이 이미 바꿀 수 없음
+	// 따라서 표시만 할 뿐 검사하는 곳은 없음 (값을 바꾸는 연산을 추가하면 Frozen 을 검사해야 함)
+	"freeze": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			}
+
+			return freeze(args[0])
+		},
+	},
 	"puts": &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			for _, arg := range args {
@@ -109,3 +176,28 @@ var builtins = map[string]*object.Builtin{
 		},
 	},
 }
+
+// 배열의 요소, 해시의 키와 값까지 재귀적으로 얼린 것으로 표시 (문자열, 숫자 등은 표시할 필요가 없으므로 그대로)
+// 이미 얼린 값은 건너뛰므로 자기 자신을 담고 있는 배열, 해시도 끝남
+func freeze(obj object.Object) object.Object {
+	switch obj := obj.(type) {
+	case *object.Array:
+		if obj.Frozen {
+			return obj
+		}
+		obj.Frozen = true
+		for _, element := range obj.Elements {
+			freeze(element)
+		}
+	case *object.Hash:
+		if obj.Frozen {
+			return obj
+		}
+		obj.Frozen = true
+		for _, pair := range obj.Pairs {
+			freeze(pair.Key)
+			freeze(pair.Value)
+		}
+	}
+	return obj
+}
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"interpreter-go/ast"
 	"interpreter-go/object"
+	"interpreter-go/token"
+	"math"
+	"sort"
+	"strings"
 )
 
 func newError(format string, a ...interface{}) *object.Error {
-	return &object.Error{Message: fmt.Sprintf(format, a...)}
+	return &object.Error{Message: fmt.Sprintf(format, a...), Kind: RUNTIME_ERROR}
 }
 
 func isError(obj object.Object) bool {
@@ -17,13 +21,45 @@ func isError(obj object.Object) bool {
 	return false
 }
 
+// 에러, return, break, continue 처럼 평가를 멈추고 그대로 바깥으로 전달해야 하는 값인지 확인
+// ex) let x = if (true) { break }; 에서 break 가 x 에 바인딩되지 않고 반복문까지 전달되어야 함
+func isAbrupt(obj object.Object) bool {
+	if obj == nil {
+		return false
+	}
+	switch obj.Type() {
+	case object.ERROR_OBJ, object.RETURN_VALUE_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+		return true
+	}
+	return false
+}
+
+const (
+	RUNTIME_ERROR = "RuntimeError" // 인터프리터가 실행 중에 만든 에러
+	USER_ERROR    = "Error"        // throw 혹은 error() 로 만든 에러
+)
+
 var (
 	NULL  = &object.Null{}
 	TRUE  = &object.Boolean{Value: true}
 	FALSE = &object.Boolean{Value: false}
+
+	BREAK    = &object.Break{}
+	CONTINUE = &object.Continue{}
 )
 
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	result := eval(node, env)
+
+	// 위치가 없는 에러는 에러를 처음 만든 (가장 안쪽의) 노드 위치로 채움
+	if err, ok := result.(*object.Error); ok && !err.Pos.IsValid() && node != nil {
+		err.Pos = node.Pos()
+	}
+
+	return result
+}
+
+func eval(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	// 명령문
 	case *ast.Program:
@@ -38,9 +74,40 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.IfExpression:
 		return evalIfExpression(node, env)
 
+	case *ast.TryExpression:
+		return evalTryExpression(node, env)
+
+	case *ast.ImportStatement:
+		return evalImportStatement(node, env)
+
+	case *ast.ExportStatement:
+		return Eval(node.Declaration, env)
+
+	case *ast.MemberExpression:
+		return evalMemberExpression(node, env)
+
+	case *ast.WhileStatement:
+		return evalWhileStatement(node, env)
+
+	case *ast.ForInStatement:
+		return evalForInStatement(node, env)
+
+	case *ast.BreakStatement:
+		return BREAK
+
+	case *ast.ContinueStatement:
+		return CONTINUE
+
+	case *ast.ThrowStatement:
+		val := Eval(node.Value, env)
+		if isAbrupt(val) {
+			return val
+		}
+		return throwValue(val)
+
 	case *ast.ReturnStatement:
 		val := Eval(node.ReturnValue, env)
-		if isError(val) {
+		if isAbrupt(val) {
 			return val
 		}
 		return &object.ReturnValue{Value: val}
@@ -48,43 +115,60 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	// 함수가 리터럴로 변수에 할당된 경우 -> FunctionLiteral로 평가된 val을 변수명과 env에 저장
 	case *ast.LetStatement:
 		val := Eval(node.Value, env)
-		if isError(val) {
+		if isAbrupt(val) {
 			return val
 		}
-		env.Set(node.Name.Value, val)
+		if node.Pattern != nil {
+			return evalDestructuring(node.Pattern, val, env)
+		}
+		if err := declare(env, node.Name.Value, val, node.Token.Type == token.CONST); err != nil {
+			return err
+		}
 
 	// 표현식들만 실제로 평가 진행
 	case *ast.IntegerLiteral:
 		return &object.Integer{Value: node.Value}
 
+	case *ast.FloatLiteral:
+		return &object.Float{Value: node.Value}
+
 	case *ast.Boolean:
 		return nativeBoolToBooleanObject(node.Value)
 
 	case *ast.PrefixExpression:
 		right := Eval(node.Right, env)
-		if isError(right) {
+		if isAbrupt(right) {
 			return right
 		}
 		return evalPrefixExpression(node.Operator, right)
 
 	case *ast.InfixExpression:
+		if node.Operator == "&&" || node.Operator == "||" {
+			return evalLogicalExpression(node, env)
+		}
+
 		left := Eval(node.Left, env)
-		if isError(left) {
+		if isAbrupt(left) {
 			return left
 		}
 		right := Eval(node.Right, env)
-		if isError(right) {
+		if isAbrupt(right) {
 			return right
 		}
 		return evalInfixExpression(node.Operator, left, right)
 
+	case *ast.AssignExpression:
+		return evalAssignExpression(node, env)
+
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
 
 	case *ast.FunctionLiteral:
-		params := node.Parameters
-		body := node.Body
-		return &object.Function{Parameters: params, Body: body, Env: env}
+		return newFunction(node, env)
+
+	// 함수 선언문은 블록을 평가하기 전에 hoistFunctions에서 이미 바인딩됨
+	case *ast.FunctionStatement:
+		return nil
 
 	case *ast.CallExpression:
 		// 변수를 호출한 경우 (=node.Function이 Identifier인 경우)
@@ -92,14 +176,23 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 		// 즉시실행함수인 경우 (=node.Function이 FunctionLiteral인 경우)
 		// -> object.Function 생성하여 바로 리턴
+
+		// quote 는 인자를 평가하지 않고 AST 그대로 감쌈
+		if isCallTo(node, "quote") {
+			if len(node.Arguments) != 1 {
+				return newError("wrong number of arguments to quote. got=%d, want=1", len(node.Arguments))
+			}
+			return quote(node.Arguments[0], env)
+		}
+
 		function := Eval(node.Function, env)
-		if isError(function) {
+		if isAbrupt(function) {
 			return function
 		}
 
 		// 실제로 들어온 인자들을 평가
 		args := evalExpressions(node.Arguments, env)
-		if len(args) == 1 && isError(args[0]) {
+		if len(args) == 1 && isAbrupt(args[0]) {
 			return args[0]
 		}
 
@@ -108,28 +201,41 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		// (함수 평가 당시의 env를 사용해도 상위의 env는 참조로 가지고 있기 때문에 함수 평가 이후에 외부 스코프의 평가값이 바뀌어도 괜찮음)
 		return applyFunction(function, args)
 
+	case *ast.MacroLiteral:
+		// 매크로는 DefineMacros 에서 등록되고 실행 전에 모두 확장됨
+		return newError("macro must be defined by a top-level let statement")
+
 	case *ast.StringLiteral:
 		return &object.String{Value: node.Value}
 
+	case *ast.InterpolatedString:
+		return evalInterpolatedString(node, env)
+
 	case *ast.ArrayLiteral:
 		elements := evalExpressions(node.Elements, env)
-		if len(elements) == 1 && isError(elements[0]) {
+		if len(elements) == 1 && isAbrupt(elements[0]) {
 			return elements[0]
 		}
 		return &object.Array{Elements: elements}
 
 	case *ast.IndexExpression:
 		left := Eval(node.Left, env)
-		if isError(left) {
+		if isAbrupt(left) {
 			return left
 		}
 		index := Eval(node.Index, env)
 
-		if isError(index) {
+		if isAbrupt(index) {
 			return index
 		}
 		return evalIndexExpression(left, index)
 
+	case *ast.SliceExpression:
+		return evalSliceExpression(node, env)
+
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env)
+
 	case *ast.HashLiteral:
 		return evalHashLiteral(node, env)
 	}
@@ -140,6 +246,10 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 func evalProgram(statements []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object
 
+	if err := hoistFunctions(statements, env); err != nil {
+		return err
+	}
+
 	for _, statement := range statements {
 		result = Eval(statement, env)
 
@@ -157,13 +267,19 @@ func evalProgram(statements []ast.Statement, env *object.Environment) object.Obj
 func evalBlockStatements(statements []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object
 
+	if err := hoistFunctions(statements, env); err != nil {
+		return err
+	}
+
 	for _, statement := range statements {
 		result = Eval(statement, env)
 
 		// return 혹은 에러를 만나면 evalProgram()까지 거슬러 올라가서 처리됨
+		// break, continue를 만나면 가장 가까운 반복문까지 거슬러 올라가서 처리됨
 		if result != nil {
 			rt := result.Type()
-			if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ {
+			if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ ||
+				rt == object.BREAK_OBJ || rt == object.CONTINUE_OBJ {
 				return result
 			}
 		}
@@ -172,6 +288,65 @@ func evalBlockStatements(statements []ast.Statement, env *object.Environment) ob
 	return result
 }
 
+// 블록의 함수 선언문을 다른 구문보다 먼저 바인딩
+// 서로를 호출하는 함수도 선언 순서와 상관없이 사용할 수 있음
+// 앞에서 const 로 선언한 이름을 함수로 다시 선언하면 (먼저 바인딩되더라도) 함수 선언문을 에러로 가리킴
+func hoistFunctions(statements []ast.Statement, env *object.Environment) *object.Error {
+	constants := map[string]bool{}
+
+	for _, statement := range statements {
+		if es, ok := statement.(*ast.ExportStatement); ok {
+			statement = es.Declaration
+		}
+		if ls, ok := statement.(*ast.LetStatement); ok && ls.Token.Type == token.CONST && ls.Name != nil {
+			constants[ls.Name.Value] = true
+		}
+		if fs, ok := statement.(*ast.FunctionStatement); ok {
+			if constants[fs.Name.Value] {
+				err := newError("cannot redeclare constant %s", fs.Name.Value)
+				err.Pos = fs.Pos()
+				return err
+			}
+			if err := declare(env, fs.Name.Value, newFunction(fs.Function, env), false); err != nil {
+				err.Pos = fs.Pos()
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 현재 환경에 이름을 선언
+// 같은 환경의 상수는 다시 선언할 수 없고, 이미 선언된 이름을 상수로 다시 선언할 수도 없음 (안쪽 환경에서 가리는 것은 허용)
+func declare(env *object.Environment, name string, val object.Object, constant bool) *object.Error {
+	if env.Declared(name) {
+		if env.IsConst(name) {
+			return newError("cannot redeclare constant %s", name)
+		}
+		if constant {
+			return newError("cannot redeclare %s as constant", name)
+		}
+	}
+
+	if constant {
+		env.SetConst(name, val)
+	} else {
+		env.Set(name, val)
+	}
+	return nil
+}
+
+func newFunction(fl *ast.FunctionLiteral, env *object.Environment) *object.Function {
+	return &object.Function{
+		Name:       fl.Name,
+		Parameters: fl.Parameters,
+		Defaults:   fl.Defaults,
+		Rest:       fl.Rest,
+		Body:       fl.Body,
+		Env:        env,
+	}
+}
+
 func nativeBoolToBooleanObject(input bool) *object.Boolean {
 	if input {
 		return TRUE
@@ -204,12 +379,14 @@ func evalBangOperatorExpression(right object.Object) object.Object {
 }
 
 func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
-	if right.Type() != object.INTEGER_OBJ {
+	switch right := right.(type) {
+	case *object.Integer:
+		return &object.Integer{Value: -right.Value}
+	case *object.Float:
+		return &object.Float{Value: -right.Value}
+	default:
 		return newError("unknown operator: -%s", right.Type())
 	}
-
-	value := right.(*object.Integer).Value
-	return &object.Integer{Value: -value}
 }
 
 func evalInfixExpression(
@@ -219,6 +396,8 @@ func evalInfixExpression(
 	switch {
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
 		return evalIntegerInfixExpression(operator, left, right)
+	case isNumber(left) && isNumber(right):
+		return evalFloatInfixExpression(operator, left, right)
 	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
 		return evalStringInfixExpression(operator, left, right)
 	case operator == "==":
@@ -246,11 +425,23 @@ func evalIntegerInfixExpression(
 	case "*":
 		return &object.Integer{Value: leftValue * rightValue}
 	case "/":
+		if rightValue == 0 {
+			return newError("division by zero")
+		}
 		return &object.Integer{Value: leftValue / rightValue}
+	case "%":
+		if rightValue == 0 {
+			return newError("division by zero")
+		}
+		return &object.Integer{Value: leftValue % rightValue}
 	case "<":
 		return nativeBoolToBooleanObject(leftValue < rightValue)
 	case ">":
 		return nativeBoolToBooleanObject(leftValue > rightValue)
+	case "<=":
+		return nativeBoolToBooleanObject(leftValue <= rightValue)
+	case ">=":
+		return nativeBoolToBooleanObject(leftValue >= rightValue)
 	case "==":
 		return nativeBoolToBooleanObject(leftValue == rightValue)
 	case "!=":
@@ -260,6 +451,52 @@ func evalIntegerInfixExpression(
 	}
 }
 
+// 정수와 실수가 섞이면 정수를 실수로 승격시켜서 계산 (정수끼리의 연산은 정수 그대로)
+func evalFloatInfixExpression(
+	operator string,
+	left, right object.Object,
+) object.Object {
+	leftValue := toFloat(left)
+	rightValue := toFloat(right)
+	switch operator {
+	case "+":
+		return &object.Float{Value: leftValue + rightValue}
+	case "-":
+		return &object.Float{Value: leftValue - rightValue}
+	case "*":
+		return &object.Float{Value: leftValue * rightValue}
+	case "/":
+		return &object.Float{Value: leftValue / rightValue}
+	case "%":
+		return &object.Float{Value: math.Mod(leftValue, rightValue)}
+	case "<":
+		return nativeBoolToBooleanObject(leftValue < rightValue)
+	case ">":
+		return nativeBoolToBooleanObject(leftValue > rightValue)
+	case "<=":
+		return nativeBoolToBooleanObject(leftValue <= rightValue)
+	case ">=":
+		return nativeBoolToBooleanObject(leftValue >= rightValue)
+	case "==":
+		return nativeBoolToBooleanObject(leftValue == rightValue)
+	case "!=":
+		return nativeBoolToBooleanObject(leftValue != rightValue)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func isNumber(obj object.Object) bool {
+	return obj.Type() == object.INTEGER_OBJ || obj.Type() == object.FLOAT_OBJ
+}
+
+func toFloat(obj object.Object) float64 {
+	if integer, ok := obj.(*object.Integer); ok {
+		return float64(integer.Value)
+	}
+	return obj.(*object.Float).Value
+}
+
 func evalStringInfixExpression(
 	operator string,
 	left, right object.Object,
@@ -277,14 +514,60 @@ func evalStringInfixExpression(
 		return nativeBoolToBooleanObject(leftVal > rightVal)
 	case "<":
 		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal >= rightVal)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal <= rightVal)
 	default:
 		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
+// &&, || 는 좌측 피연산자만으로 결과가 정해지면 우측은 평가하지 않음 (단락 평가)
+func evalLogicalExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	left := Eval(node.Left, env)
+	if isAbrupt(left) {
+		return left
+	}
+
+	if node.Operator == "&&" && !isTruthy(left) {
+		return FALSE
+	}
+	if node.Operator == "||" && isTruthy(left) {
+		return TRUE
+	}
+
+	right := Eval(node.Right, env)
+	if isAbrupt(right) {
+		return right
+	}
+
+	return nativeBoolToBooleanObject(isTruthy(right))
+}
+
+// 보간식은 평가 후 Inspect() 결과를 이어붙임
+func evalInterpolatedString(node *ast.InterpolatedString, env *object.Environment) object.Object {
+	var out strings.Builder
+
+	for _, part := range node.Parts {
+		if literal, ok := part.(*ast.StringLiteral); ok {
+			out.WriteString(literal.Value)
+			continue
+		}
+
+		value := Eval(part, env)
+		if isAbrupt(value) {
+			return value
+		}
+		out.WriteString(value.Inspect())
+	}
+
+	return &object.String{Value: out.String()}
+}
+
 func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
 	condition := Eval(ie.Condition, env)
-	if isError(condition) {
+	if isAbrupt(condition) {
 		return condition
 	}
 
@@ -297,6 +580,161 @@ func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Obje
 	}
 }
 
+// 본문은 회차마다 새 환경에서 평가 (본문의 const 가 다음 회차에서 재선언이 되지 않도록 함)
+func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment) object.Object {
+	for {
+		condition := Eval(ws.Condition, env)
+		if isAbrupt(condition) {
+			return condition
+		}
+		if !isTruthy(condition) {
+			break
+		}
+
+		if result, stop := evalLoopBody(ws.Body, object.NewEnclosedEnvironment(env)); stop {
+			if result != nil {
+				return result
+			}
+			break
+		}
+	}
+
+	return NULL
+}
+
+// 던진 값을 전파되는 에러로 바꿈
+// 에러 값이 아닌 값을 던지면 그 값을 data 로 갖는 Error 종류의 에러가 됨
+func throwValue(val object.Object) *object.Error {
+	switch val := val.(type) {
+	case *object.ErrorValue:
+		// 잡았던 에러를 다시 던지면 처음 던져진 위치를 유지
+		return &object.Error{Message: val.Message, Kind: val.Kind, Data: val.Data, Pos: val.Pos}
+	case *object.String:
+		return &object.Error{Message: val.Value, Kind: USER_ERROR, Data: val}
+	default:
+		return &object.Error{Message: val.Inspect(), Kind: USER_ERROR, Data: val}
+	}
+}
+
+// try 블록에서 에러가 나면 catch 블록에서 에러 값으로 받아서 처리
+// finally 블록은 항상 실행되고, finally 에서 return, break, continue, 에러가 나면 그 결과가 우선함
+func evalTryExpression(te *ast.TryExpression, env *object.Environment) object.Object {
+	result := Eval(te.Block, env)
+
+	if err, ok := result.(*object.Error); ok && te.Catch != nil {
+		catchEnv := object.NewEnclosedEnvironment(env)
+		if te.CatchParam != nil {
+			catchEnv.Set(te.CatchParam.Value, &object.ErrorValue{
+				Message: err.Message,
+				Kind:    err.Kind,
+				Data:    err.Data,
+				Pos:     err.Pos,
+			})
+		}
+		result = Eval(te.Catch, catchEnv)
+	}
+
+	if te.Finally != nil {
+		finally := Eval(te.Finally, env)
+		if finally != nil {
+			switch finally.Type() {
+			case object.RETURN_VALUE_OBJ, object.ERROR_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+				return finally
+			}
+		}
+	}
+
+	return result
+}
+
+// 배열은 (인덱스, 요소), 문자열은 (인덱스, 문자), 해시는 (키, 값) 순서쌍을 순회
+// 회차마다 새 환경에 변수를 바인딩해서 클로저가 그 회차의 값을 캡처하도록 함
+func evalForInStatement(fs *ast.ForInStatement, env *object.Environment) object.Object {
+	iterable := Eval(fs.Iterable, env)
+	if isAbrupt(iterable) {
+		return iterable
+	}
+
+	var keys, values []object.Object
+	switch iterable := iterable.(type) {
+	case *object.Array:
+		for i, element := range iterable.Elements {
+			keys = append(keys, &object.Integer{Value: int64(i)})
+			values = append(values, element)
+		}
+	case *object.String:
+		for i, ch := range []rune(iterable.Value) {
+			keys = append(keys, &object.Integer{Value: int64(i)})
+			values = append(values, &object.String{Value: string(ch)})
+		}
+	case *object.Hash:
+		for _, pair := range sortedHashPairs(iterable) {
+			keys = append(keys, pair.Key)
+			values = append(values, pair.Value)
+		}
+		// 변수가 하나면 해시는 키를 순회
+		if fs.Key == nil {
+			values = keys
+		}
+	default:
+		return newError("iteration not supported: %s", iterable.Type())
+	}
+
+	for i := range values {
+		loopEnv := object.NewEnclosedEnvironment(env)
+		if fs.Key != nil {
+			loopEnv.Set(fs.Key.Value, keys[i])
+		}
+		loopEnv.Set(fs.Value.Value, values[i])
+
+		if result, stop := evalLoopBody(fs.Body, loopEnv); stop {
+			if result != nil {
+				return result
+			}
+			break
+		}
+	}
+
+	return NULL
+}
+
+// 반복문 본문을 한 번 평가하고 반복을 멈춰야 하는지 리턴
+// return, 에러는 반복문 밖으로 전달해야 하므로 함께 리턴하고, continue는 본문의 나머지만 건너뜀
+func evalLoopBody(body *ast.BlockStatement, env *object.Environment) (object.Object, bool) {
+	result := Eval(body, env)
+	if result == BREAK {
+		return nil, true
+	}
+	if result != nil {
+		rt := result.Type()
+		if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ {
+			return result, true
+		}
+	}
+	return nil, false
+}
+
+// 해시는 순서가 없으므로 키 기준으로 정렬해서 순회 순서를 고정 (정수는 크기순, 그 외는 타입, 출력값 순)
+func sortedHashPairs(hash *object.Hash) []object.HashPair {
+	pairs := make([]object.HashPair, 0, len(hash.Pairs))
+	for _, pair := range hash.Pairs {
+		pairs = append(pairs, pair)
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i].Key, pairs[j].Key
+		if a.Type() != b.Type() {
+			return a.Type() < b.Type()
+		}
+		if a, ok := a.(*object.Integer); ok {
+			return a.Value < b.(*object.Integer).Value
+		}
+		return a.Inspect() < b.Inspect()
+	})
+
+	return pairs
+}
+
 func isTruthy(obj object.Object) bool {
 	switch obj {
 	case NULL:
@@ -325,6 +763,188 @@ func evalIdentifier(
 	return newError("identifier not found: " + node.Value)
 }
 
+// 복합 대입 연산자는 현재 값과 우측 값을 중위 연산한 결과를 대입 ex) x += 1 -> x = x + 1
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
+	val := Eval(node.Value, env)
+	if isAbrupt(val) {
+		return val
+	}
+
+	name := node.Name.Value
+	if env.IsConst(name) {
+		return newError("cannot assign to constant %s", name)
+	}
+	if node.Operator != "=" {
+		current, ok := env.Get(name)
+		if !ok {
+			return newError("assignment to undeclared identifier: %s", name)
+		}
+		val = evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, val)
+		if isError(val) {
+			return val
+		}
+	}
+
+	if _, ok := env.Assign(name, val); !ok {
+		return newError("assignment to undeclared identifier: %s", name)
+	}
+	return val
+}
+
+// 구조 분해 할당: 패턴의 각 변수에 값을 바인딩 (값이 없으면 NULL)
+func evalDestructuring(pattern ast.Pattern, val object.Object, env *object.Environment) object.Object {
+	matched, err := matchPattern(pattern, val, env, false)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return newError("value %s does not match pattern %s", val.Inspect(), pattern.String())
+	}
+	return nil
+}
+
+func evalMatchExpression(node *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := Eval(node.Subject, env)
+	if isAbrupt(subject) {
+		return subject
+	}
+
+	// 갈래마다 새 환경에 패턴 변수를 바인딩하고, 패턴과 가드를 모두 통과한 첫번째 갈래를 평가
+	for _, arm := range node.Arms {
+		armEnv := object.NewEnclosedEnvironment(env)
+		matched, err := matchPattern(arm.Pattern, subject, armEnv, true)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		if arm.Guard != nil {
+			guard := Eval(arm.Guard, armEnv)
+			if isAbrupt(guard) {
+				return guard
+			}
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+
+		return Eval(arm.Body, armEnv)
+	}
+
+	return newError("non-exhaustive match: no pattern matched %s", subject.Inspect())
+}
+
+// 값이 패턴과 매칭되는지 확인하고 패턴의 변수를 env에 바인딩
+// strict가 true면 (match) 배열 길이와 해시 키가 패턴과 맞아야 하고, 타입이 다르면 매칭 실패
+// strict가 false면 (let 구조 분해) 없는 값은 NULL로 바인딩하고, 타입이 다르면 에러
+func matchPattern(pattern ast.Pattern, val object.Object, env *object.Environment, strict bool) (bool, object.Object) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		if pattern.Value != "_" {
+			if err := declare(env, pattern.Value, val, false); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case *ast.LiteralPattern:
+		literal := Eval(pattern.Value, env)
+		if isAbrupt(literal) {
+			return false, literal
+		}
+		return objectsEqual(literal, val), nil
+
+	case *ast.ArrayPattern:
+		array, ok := val.(*object.Array)
+		if !ok {
+			if strict {
+				return false, nil
+			}
+			return false, newError("cannot destructure %s as ARRAY", val.Type())
+		}
+
+		length := len(array.Elements)
+		if strict && (length < len(pattern.Elements) || pattern.Rest == nil && length > len(pattern.Elements)) {
+			return false, nil
+		}
+
+		for i, element := range pattern.Elements {
+			var item object.Object = NULL
+			if i < length {
+				item = array.Elements[i]
+			}
+			if matched, err := matchPattern(element, item, env, strict); !matched || err != nil {
+				return matched, err
+			}
+		}
+
+		if pattern.Rest != nil && pattern.Rest.Value != "_" {
+			rest := []object.Object{}
+			if len(pattern.Elements) < length {
+				rest = append(rest, array.Elements[len(pattern.Elements):]...)
+			}
+			if err := declare(env, pattern.Rest.Value, &object.Array{Elements: rest}, false); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case *ast.HashPattern:
+		hash, ok := val.(*object.Hash)
+		if !ok {
+			if strict {
+				return false, nil
+			}
+			return false, newError("cannot destructure %s as HASH", val.Type())
+		}
+
+		for _, pair := range pattern.Pairs {
+			key := Eval(pair.Key, env)
+			if isAbrupt(key) {
+				return false, key
+			}
+			hashKey, ok := key.(object.Hashable)
+			if !ok {
+				return false, newError("unusable as hash key: %s", key.Type())
+			}
+
+			var value object.Object = NULL
+			if hashPair, ok := hash.Pairs[hashKey.HashKey()]; ok {
+				value = hashPair.Value
+			} else if strict {
+				return false, nil
+			}
+			if matched, err := matchPattern(pair.Value, value, env, strict); !matched || err != nil {
+				return matched, err
+			}
+		}
+		return true, nil
+	}
+
+	return false, newError("unknown pattern: %s", pattern.String())
+}
+
+// 리터럴 패턴 비교: 정수와 실수는 값으로 비교하고, 그 외에는 타입과 값이 모두 같아야 함
+func objectsEqual(a, b object.Object) bool {
+	if isNumber(a) && isNumber(b) {
+		return toFloat(a) == toFloat(b)
+	}
+
+	switch a := a.(type) {
+	case *object.String:
+		b, ok := b.(*object.String)
+		return ok && a.Value == b.Value
+	case *object.Boolean:
+		b, ok := b.(*object.Boolean)
+		return ok && a.Value == b.Value
+	case *object.Null:
+		return b == NULL
+	}
+	return false
+}
+
 func evalExpressions(
 	expressions []ast.Expression,
 	env *object.Environment,
@@ -333,7 +953,7 @@ func evalExpressions(
 
 	for _, expression := range expressions {
 		evaluated := Eval(expression, env)
-		if isError(evaluated) {
+		if isAbrupt(evaluated) {
 			return []object.Object{evaluated}
 		}
 		result = append(result, evaluated)
@@ -346,8 +966,14 @@ func applyFunction(fn object.Object, args []object.Object) object.Object {
 	switch fn := fn.(type) {
 	case *object.Function:
 		// 환경을 확장하여 함수 body 평가
-		extendedEnv := extendedFunctionEnv(fn, args)
+		extendedEnv, err := extendedFunctionEnv(fn, args)
+		if err != nil {
+			return err
+		}
 		evaluated := Eval(fn.Body, extendedEnv)
+		if err, ok := evaluated.(*object.Error); ok {
+			addFrame(err, fn)
+		}
 		return unwrapReturnValue(evaluated)
 
 	case *object.Builtin:
@@ -361,15 +987,73 @@ func applyFunction(fn object.Object, args []object.Object) object.Object {
 func extendedFunctionEnv(
 	fn *object.Function,
 	args []object.Object,
-) *object.Environment {
+) (*object.Environment, object.Object) {
+	if err := checkArity(fn, len(args)); err != nil {
+		return nil, err
+	}
+
 	env := object.NewEnclosedEnvironment(fn.Env)
 
 	// 파라미터의 변수명과 호출 시 넣어준 인자를 순서대로 매칭시켜 K:V 형태로 저장
+	// 인자가 없는 파라미터는 기본값을 평가해서 저장 (앞의 파라미터를 참조할 수 있도록 함수 환경에서 평가)
 	for paramIdx, param := range fn.Parameters {
-		env.Set(param.Value, args[paramIdx])
+		if paramIdx < len(args) {
+			env.Set(param.Value, args[paramIdx])
+			continue
+		}
+		val := Eval(fn.Defaults[param.Value], env)
+		if isError(val) {
+			return nil, val
+		}
+		env.Set(param.Value, val)
 	}
 
-	return env
+	// 남은 인자는 가변 파라미터에 배열로 저장
+	if fn.Rest != nil {
+		rest := []object.Object{}
+		if len(args) > len(fn.Parameters) {
+			rest = append(rest, args[len(fn.Parameters):]...)
+		}
+		env.Set(fn.Rest.Value, &object.Array{Elements: rest})
+	}
+
+	return env, nil
+}
+
+// 인자 개수 검사: 기본값이 없는 파라미터 수 이상, 가변 파라미터가 없으면 전체 파라미터 수 이하
+// 이름 있는 함수면 에러 메시지 앞에 함수 이름을 붙임 ex) add: wrong number of arguments: want 2, got 1
+func checkArity(fn *object.Function, got int) object.Object {
+	required := len(fn.Parameters) - len(fn.Defaults)
+	max := len(fn.Parameters)
+
+	var want string
+	switch {
+	case fn.Rest != nil && got < required:
+		want = fmt.Sprintf("at least %d", required)
+	case fn.Rest == nil && required == max && got != max:
+		want = fmt.Sprintf("%d", max)
+	case fn.Rest == nil && (got < required || got > max):
+		want = fmt.Sprintf("%d..%d", required, max)
+	default:
+		return nil
+	}
+
+	if fn.Name != "" {
+		return newError("%s: wrong number of arguments: want %s, got %d", fn.Name, want, got)
+	}
+	return newError("wrong number of arguments: want %s, got %d", want, got)
+}
+
+// 함수 본문에서 빠져나온 에러에 함수 이름을 기록 (익명 함수는 기록하지 않음)
+// 재귀 호출로 같은 함수를 연달아 빠져나오면 한 번만 기록
+func addFrame(err *object.Error, fn *object.Function) {
+	if fn.Name == "" {
+		return
+	}
+	if n := len(err.Frames); n > 0 && err.Frames[n-1] == fn.Name {
+		return
+	}
+	err.Frames = append(err.Frames, fn.Name)
 }
 
 func unwrapReturnValue(obj object.Object) object.Object {
@@ -386,23 +1070,117 @@ func evalIndexExpression(left, index object.Object) object.Object {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
 		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.STRING_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalStringIndexExpression(left, index)
 	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpression(left, index)
+	case left.Type() == object.ERROR_VALUE_OBJ && index.Type() == object.STRING_OBJ:
+		return evalErrorValueIndexExpression(left, index)
 	default:
 		return newError("index operator not supported: %s", left.Type())
 	}
 }
 
+// 음수 인덱스는 뒤에서부터 셈 ex) -1 -> 마지막 요소
+// 음수를 변환한 후에도 범위를 벗어나면 NULL
 func evalArrayIndexExpression(array, index object.Object) object.Object {
 	arrayObject := array.(*object.Array)
-	idx := index.(*object.Integer).Value
-	max := int64(len(arrayObject.Elements) - 1)
-	if idx < 0 || idx > max {
+	idx, ok := normalizeIndex(index.(*object.Integer).Value, len(arrayObject.Elements))
+	if !ok {
 		return NULL
 	}
 	return arrayObject.Elements[idx]
 }
 
+// 문자열은 바이트가 아니라 문자(rune) 단위로 인덱싱
+func evalStringIndexExpression(str, index object.Object) object.Object {
+	runes := []rune(str.(*object.String).Value)
+	idx, ok := normalizeIndex(index.(*object.Integer).Value, len(runes))
+	if !ok {
+		return NULL
+	}
+	return &object.String{Value: string(runes[idx])}
+}
+
+func normalizeIndex(idx int64, length int) (int64, bool) {
+	if idx < 0 {
+		idx += int64(length)
+	}
+	if idx < 0 || idx >= int64(length) {
+		return 0, false
+	}
+	return idx, true
+}
+
+// 슬라이스는 항상 새 배열/문자열을 리턴
+// 범위는 파이썬과 같이 처리: 음수는 뒤에서부터 세고, 범위를 벗어나면 양 끝으로 맞추며, start >= end 이면 빈 값
+func evalSliceExpression(node *ast.SliceExpression, env *object.Environment) object.Object {
+	left := Eval(node.Left, env)
+	if isAbrupt(left) {
+		return left
+	}
+
+	var length int
+	switch left := left.(type) {
+	case *object.Array:
+		length = len(left.Elements)
+	case *object.String:
+		length = len([]rune(left.Value))
+	default:
+		return newError("slice operator not supported: %s", left.Type())
+	}
+
+	start, err := evalSliceBound(node.Start, env, 0, length)
+	if err != nil {
+		return err
+	}
+	end, err := evalSliceBound(node.Stop, env, length, length)
+	if err != nil {
+		return err
+	}
+	if start > end {
+		start = end
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		elements := make([]object.Object, end-start)
+		copy(elements, left.Elements[start:end])
+		return &object.Array{Elements: elements}
+	default:
+		runes := []rune(left.(*object.String).Value)
+		return &object.String{Value: string(runes[start:end])}
+	}
+}
+
+// 생략된 범위는 defaultValue, 그 외에는 0 이상 length 이하로 맞춘 값을 리턴
+func evalSliceBound(node ast.Expression, env *object.Environment, defaultValue, length int) (int, object.Object) {
+	if node == nil {
+		return defaultValue, nil
+	}
+
+	bound := Eval(node, env)
+	if isAbrupt(bound) {
+		return 0, bound
+	}
+	integer, ok := bound.(*object.Integer)
+	if !ok {
+		return 0, newError("slice index must be INTEGER, got %s", bound.Type())
+	}
+
+	idx := integer.Value
+	if idx < 0 {
+		idx += int64(length)
+	}
+	if idx < 0 {
+		return 0, nil
+	}
+	if idx > int64(length) {
+		return length, nil
+	}
+	return int(idx), nil
+}
+
 func evalHashLiteral(
 	node *ast.HashLiteral,
 	env *object.Environment,
@@ -411,7 +1189,7 @@ func evalHashLiteral(
 
 	for keyNode, valueNode := range node.Pairs {
 		key := Eval(keyNode, env)
-		if isError(key) {
+		if isAbrupt(key) {
 			return key
 		}
 
@@ -422,7 +1200,7 @@ func evalHashLiteral(
 		}
 
 		value := Eval(valueNode, env)
-		if isError(value) {
+		if isAbrupt(value) {
 			return value
 		}
 
@@ -433,6 +1211,41 @@ func evalHashLiteral(
 	return &object.Hash{Pairs: pairs}
 }
 
+// 에러 값의 필드 조회 ex) e["message"], e["kind"], e["data"], e["line"], e["column"]
+// 없는 필드는 해시처럼 NULL
+func evalErrorValueIndexExpression(left, index object.Object) object.Object {
+	errorValue := left.(*object.ErrorValue)
+
+	switch index.(*object.String).Value {
+	case "message":
+		return &object.String{Value: errorValue.Message}
+	case "kind":
+		return &object.String{Value: errorValue.Kind}
+	case "data":
+		if errorValue.Data == nil {
+			return NULL
+		}
+		return errorValue.Data
+	case "position":
+		if !errorValue.Pos.IsValid() {
+			return NULL
+		}
+		return &object.String{Value: errorValue.Pos.String()}
+	case "line":
+		if !errorValue.Pos.IsValid() {
+			return NULL
+		}
+		return &object.Integer{Value: int64(errorValue.Pos.Line)}
+	case "column":
+		if !errorValue.Pos.IsValid() {
+			return NULL
+		}
+		return &object.Integer{Value: int64(errorValue.Pos.Column)}
+	default:
+		return NULL
+	}
+}
+
 func evalHashIndexExpression(left, index object.Object) object.Object {
 	hashObject := left.(*object.Hash)
 
@@ -37,6 +37,9 @@ func TestEvalIntegerExpression(t *testing.T) {
 		{"3 * 3 * 3 + 10", 37},
 		{"3 * (3 * 3) + 10", 37},
 		{"(5 + 10 * 2 + 15 / 3) * 2 + -10", 50},
+		{"10 % 3", 1},
+		{"-7 % 3", -1},
+		{"2 + 9 % 4 * 3", 5},
 	}
 
 	for _, test := range tests {
@@ -60,6 +63,43 @@ func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
 	return true
 }
 
+// Float 표현식 평가 (정수와 섞이면 실수로 승격)
+func TestEvalFloatExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.5", 3.5},
+		{"-2.5", -2.5},
+		{"1.5 + 1.5", 3.0},
+		{"1 + 0.5", 1.5},
+		{"0.5 * 4", 2.0},
+		{"7 / 2.0", 3.5},
+		{"10 - 2.5 * 2", 5.0},
+		{"1.5e-3 * 1000", 1.5},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		testFloatObject(t, evaluated, test.expected)
+	}
+}
+
+func testFloatObject(t *testing.T, obj object.Object, expected float64) bool {
+	result, ok := obj.(*object.Float)
+	if !ok {
+		t.Errorf("object is not Float. got=%T (%+v)", obj, obj)
+		return false
+	}
+
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%g, want=%g", result.Value, expected)
+		return false
+	}
+
+	return true
+}
+
 // Integer 표현식 평가
 func TestEvalBooleanExpression(t *testing.T) {
 	tests := []struct {
@@ -85,6 +125,23 @@ func TestEvalBooleanExpression(t *testing.T) {
 		{"(1 < 2) == false", false},
 		{"(1 > 2) == true", false},
 		{"(1 > 2) == false", true},
+		{"1 < 1.5", true},
+		{"2.5 > 3", false},
+		{"1 == 1.0", true},
+		{"0.1 + 0.2 != 0.3", true},
+		{"1 <= 1", true},
+		{"2 <= 1", false},
+		{"1 >= 1", true},
+		{"1 >= 2", false},
+		{"1.5 >= 1", true},
+		{`"a" <= "b"`, true},
+		{`"b" >= "c"`, false},
+		{"true && true", true},
+		{"true && false", false},
+		{"false || true", true},
+		{"false || false", false},
+		{"1 < 2 && 2 < 3", true},
+		{"!(1 > 2) || false", true},
 	}
 
 	for _, test := range tests {
@@ -179,6 +236,10 @@ func TestReturnStatements(t *testing.T) {
 			`,
 			10,
 		},
+		// 값 자리에서 만난 return 은 값으로 바인딩되지 않고 함수를 빠져나감
+		{"let f = fn() { let x = if (true) { return 1 }; 2 }; f();", 1},
+		{"let f = fn() { [if (true) { return 1 }, 2]; 3 }; f();", 1},
+		{"let f = fn(a) { a }; let g = fn() { f(if (true) { return 1 }); 2 }; g();", 1},
 	}
 
 	for _, test := range tests {
@@ -240,6 +301,26 @@ func TestErrorHandling(t *testing.T) {
 			`{"name": "Monkey"}[fn(x) { x }];`,
 			"unusable as hash key: FUNCTION",
 		},
+		{
+			"5 / 0",
+			"division by zero",
+		},
+		{
+			"5 % (2 - 2)",
+			"division by zero",
+		},
+		{
+			"x = 5;",
+			"assignment to undeclared identifier: x",
+		},
+		{
+			"let f = fn() { y += 1; }; f();",
+			"assignment to undeclared identifier: y",
+		},
+		{
+			`let s = "a"; s -= "b";`,
+			"unknown operator: STRING - STRING",
+		},
 	}
 
 	for i, test := range tests {
@@ -317,6 +398,184 @@ func TestFunctionApplication(t *testing.T) {
 	}
 }
 
+func TestFunctionStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"fn add(a, b) { a + b } add(1, 2);", 3},
+		// 선언보다 먼저 호출할 수 있음
+		{"let x = double(4); fn double(n) { n * 2 } x;", 8},
+		// 서로를 호출하는 재귀 함수
+		{`
+		fn isEven(n) { if (n == 0) { true } else { isOdd(n - 1) } }
+		fn isOdd(n) { if (n == 0) { false } else { isEven(n - 1) } }
+		if (isEven(10) && isOdd(7)) { 1 } else { 0 }
+		`, 1},
+		// 함수 안의 선언은 함수 안에서만 보임
+		{`
+		fn outer() {
+			let r = inner();
+			fn inner() { 42 }
+			r
+		}
+		outer();
+		`, 42},
+		{"fn fact(n) { if (n <= 1) { return 1; } n * fact(n - 1) } fact(5);", 120},
+	}
+
+	for _, test := range tests {
+		testIntegerObject(t, testEval(test.input), test.expected)
+	}
+
+	evaluated := testEval("fn outer() { fn inner() { 1 } 0 } outer(); inner();")
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+	}
+	if errObj.Message != "identifier not found: inner" {
+		t.Errorf("wrong error message. got=%q", errObj.Message)
+	}
+}
+
+func TestFunctionName(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedName    string
+		expectedInspect string
+	}{
+		{"fn add(a, b) { a + b } add;", "add", "fn add(a, b) {\n(a + b)\n}"},
+		{"let sub = fn(a, b = 1) { a - b }; sub;", "sub", "fn sub(a, b = 1) {\n(a - b)\n}"},
+		{"fn(x) { x };", "", "fn(x) {\nx\n}"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		fn, ok := evaluated.(*object.Function)
+		if !ok {
+			t.Fatalf("object is not Function. got=%T (%+v)", evaluated, evaluated)
+		}
+		if fn.Name != test.expectedName {
+			t.Errorf("fn.Name wrong. expected=%q, got=%q", test.expectedName, fn.Name)
+		}
+		if fn.Inspect() != test.expectedInspect {
+			t.Errorf("fn.Inspect() wrong. expected=%q, got=%q", test.expectedInspect, fn.Inspect())
+		}
+	}
+}
+
+func TestLambdas(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let double = |x| x * 2; double(5);", 10},
+		{"(|x, y| x + y)(2, 3);", 5},
+		{"let answer = || 42; answer();", 42},
+		{"let add = |a, b = 10| a + b; add(1);", 11},
+		{"let apply = fn(f, x) { f(x) }; apply(|x| x * x, 7);", 49},
+		// 클로저
+		{"let adder = |x| |y| x + y; adder(2)(3);", 5},
+		{"let count = |...xs| len(xs); count(1, 2, 3);", 3},
+	}
+
+	for _, test := range tests {
+		testIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestPipelineExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		// 내장 함수
+		{"[1, 2, 3] |> len()", 3},
+		{"[1, 2, 3] |> push(4) |> len", 4},
+		{`"hello" |> len`, 5},
+		// 사용자 함수와 람다
+		{"let double = |x| x * 2; 5 |> double", 10},
+		{"let sub = fn(a, b) { a - b }; 10 |> sub(3)", 7},
+		{"3 |> (|x| x * x)", 9},
+		{`
+		fn map(xs, f) {
+			let result = [];
+			for (x in xs) { result = push(result, f(x)); }
+			result
+		}
+		fn filter(xs, f) {
+			let result = [];
+			for (x in xs) { if (f(x)) { result = push(result, x); } }
+			result
+		}
+		fn sum(xs) {
+			let total = 0;
+			for (x in xs) { total += x; }
+			total
+		}
+		range(1, 6) |> map(|x| x * x) |> filter(|x| x % 2 == 1) |> sum()
+		`, 35},
+	}
+
+	for _, test := range tests {
+		testIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestFunctionDefaultAndRestParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let add = fn(a, b = 10) { a + b }; add(1);", 11},
+		{"let add = fn(a, b = 10) { a + b }; add(1, 2);", 3},
+		// 기본값은 호출할 때마다 평가되고 앞의 파라미터를 참조할 수 있음
+		{"let f = fn(a, b = a * 2) { a + b }; f(3);", 9},
+		{"let n = 1; let f = fn(a = n) { a }; n = 5; f();", 5},
+		{"let count = fn(...args) { len(args) }; count();", 0},
+		{"let count = fn(...args) { len(args) }; count(1, 2, 3);", 3},
+		{"let f = fn(first, ...rest) { first + len(rest) }; f(10, 20, 30);", 12},
+		{"let f = fn(first, ...rest) { rest[0] }; f(10, 20, 30);", 20},
+		{"let f = fn(a, b = 2, ...rest) { a + b + len(rest) }; f(1);", 3},
+		{"let f = fn(a, b = 2, ...rest) { a + b + len(rest) }; f(1, 5, 0, 0);", 8},
+	}
+
+	for _, test := range tests {
+		testIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestFunctionArity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let add = fn(a, b) { a + b }; add(1);", "add: wrong number of arguments: want 2, got 1"},
+		{"let add = fn(a, b) { a + b }; add(1, 2, 3);", "add: wrong number of arguments: want 2, got 3"},
+		{"fn() { 1 }(1);", "wrong number of arguments: want 0, got 1"},
+		{"let add = fn(a, b = 1) { a + b }; add();", "add: wrong number of arguments: want 1..2, got 0"},
+		{"let add = fn(a, b = 1) { a + b }; add(1, 2, 3);", "add: wrong number of arguments: want 1..2, got 3"},
+		{"let f = fn(a, ...rest) { a }; f();", "f: wrong number of arguments: want at least 1, got 0"},
+		{"let f = fn(a = b) { a }; f();", "identifier not found: b"},
+		// 함수 선언문과 익명 함수
+		{"fn add(a, b) { a + b } add(1);", "add: wrong number of arguments: want 2, got 1"},
+		{"[fn(a) { a }][0]();", "wrong number of arguments: want 1, got 0"},
+		{"fn outer() { fn(a) { a }() } outer();", "wrong number of arguments: want 1, got 0"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%q - no error object returned. got=%T(%+v)", test.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != test.expected {
+			t.Errorf("%q - wrong error message. expected=%q, got=%q", test.input, test.expected, errObj.Message)
+		}
+	}
+}
+
 func TestClosures(t *testing.T) {
 	input := `
 		let newAdder = fn(x) {
@@ -365,8 +624,16 @@ func TestBuiltinFunctions(t *testing.T) {
 		{`len("")`, 0},
 		{`len("four")`, 4},
 		{`len("hello world")`, 11},
+		{`len("안녕하세요")`, 5},
 		{`len(1)`, "argument to len not supported, got INTEGER"},
 		{`len("one", "two")`, "wrong number of arguments. got=2, want=1"},
+		{`range(3)`, []int{0, 1, 2}},
+		{`range(2, 5)`, []int{2, 3, 4}},
+		{`range(1, 10, 3)`, []int{1, 4, 7}},
+		{`range(5, 0, -2)`, []int{5, 3, 1}},
+		{`range(5, 0)`, []int{}},
+		{`range(0, 5, 0)`, "range step must not be zero"},
+		{`range("3")`, "argument to range must be INTEGER, got STRING"},
 	}
 	for _, test := range tests {
 		evaluated := testEval(test.input)
@@ -384,6 +651,19 @@ func TestBuiltinFunctions(t *testing.T) {
 				t.Errorf("wrong error message. expected=%q, got=%q",
 					expected, errObj.Message)
 			}
+		case []int:
+			array, ok := evaluated.(*object.Array)
+			if !ok {
+				t.Errorf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if len(array.Elements) != len(expected) {
+				t.Errorf("wrong num of elements. want=%d, got=%d", len(expected), len(array.Elements))
+				continue
+			}
+			for i, expectedElement := range expected {
+				testIntegerObject(t, array.Elements[i], int64(expectedElement))
+			}
 		}
 	}
 }
@@ -449,6 +729,14 @@ func TestArrayIndexExpressions(t *testing.T) {
 		},
 		{
 			"[1, 2, 3][-1]",
+			3,
+		},
+		{
+			"[1, 2, 3][-3]",
+			1,
+		},
+		{
+			"[1, 2, 3][-4]",
 			nil,
 		},
 	}
@@ -464,6 +752,73 @@ func TestArrayIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"[1, 2, 3, 4, 5][1:3]", []int{2, 3}},
+		{"[1, 2, 3, 4, 5][:2]", []int{1, 2}},
+		{"[1, 2, 3, 4, 5][3:]", []int{4, 5}},
+		{"[1, 2, 3, 4, 5][:]", []int{1, 2, 3, 4, 5}},
+		{"[1, 2, 3, 4, 5][-2:]", []int{4, 5}},
+		{"[1, 2, 3, 4, 5][:-1]", []int{1, 2, 3, 4}},
+		// 범위를 벗어나면 양 끝으로 맞춤
+		{"[1, 2, 3][1:100]", []int{2, 3}},
+		{"[1, 2, 3][-100:1]", []int{1}},
+		{"[1, 2, 3][5:]", []int{}},
+		{"[1, 2, 3][2:1]", []int{}},
+		{"let a = [1, 2, 3]; let n = 2; a[n - 1:n + 1]", []int{2, 3}},
+		{`"hello world"[2:5]`, "llo"},
+		{`"hello"[:-2]`, "hel"},
+		{`"안녕하세요"[1:3]`, "녕하"},
+		{`"hello"[10:]`, ""},
+		{`5[1:2]`, errorMessage("slice operator not supported: INTEGER")},
+		{`[1, 2][true:]`, errorMessage("slice index must be INTEGER, got BOOLEAN")},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case []int:
+			array, ok := evaluated.(*object.Array)
+			if !ok {
+				t.Errorf("%q - object is not Array. got=%T (%+v)", test.input, evaluated, evaluated)
+				continue
+			}
+			if len(array.Elements) != len(expected) {
+				t.Errorf("%q - wrong num of elements. want=%d, got=%d", test.input, len(expected), len(array.Elements))
+				continue
+			}
+			for i, expectedElement := range expected {
+				testIntegerObject(t, array.Elements[i], int64(expectedElement))
+			}
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("%q - object is not String. got=%T (%+v)", test.input, evaluated, evaluated)
+				continue
+			}
+			if str.Value != expected {
+				t.Errorf("%q - String has wrong value. expected=%q, got=%q", test.input, expected, str.Value)
+			}
+		case errorMessage:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("%q - no error object returned. got=%T (%+v)", test.input, evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != string(expected) {
+				t.Errorf("%q - wrong error message. expected=%q, got=%q", test.input, expected, errObj.Message)
+			}
+		}
+	}
+}
+
+// 테스트 테이블에서 문자열 결과와 에러 메시지를 구분하기 위한 타입
+type errorMessage string
+
 func TestHashLiterals(t *testing.T) {
 	input := `
 		let two = "two";
@@ -551,3 +906,624 @@ func TestHashIndexExpressions(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"5 + true;", "ERROR: 1:1: type mismatch: INTEGER + BOOLEAN"},
+		{"let a = 1;\nlet b = a + foobar;", "ERROR: 2:13: identifier not found: foobar"},
+		{"let f = fn() {\n  -true\n};\nf();", "ERROR: 2:3: unknown operator: -BOOLEAN (in f)"},
+		{"\nlen(1)", "ERROR: 2:1: argument to len not supported, got INTEGER"},
+		// 에러가 빠져나온 이름 있는 함수들을 안쪽부터 표시 (익명 함수와 재귀 호출은 한 번만)
+		{"fn inner() { 1 + true }\nfn outer() { inner() }\nouter();", "ERROR: 1:14: type mismatch: INTEGER + BOOLEAN (in inner <- outer)"},
+		{"let f = fn() { fn() { -true }() };\nf();", "ERROR: 1:23: unknown operator: -BOOLEAN (in f)"},
+		{"fn down(n) { if (n == 0) { -true } else { down(n - 1) } }\ndown(3);", "ERROR: 1:28: unknown operator: -BOOLEAN (in down)"},
+		{"fn() { 1 + true }();", "ERROR: 1:8: type mismatch: INTEGER + BOOLEAN"},
+		// 다시 선언한 쪽을 가리킴
+		{"const f = 5;\nfn f() { 1 }", "ERROR: 2:1: cannot redeclare constant f"},
+		{"fn f() { 1 }\nconst f = 5;", "ERROR: 2:1: cannot redeclare f as constant"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Inspect() != test.expected {
+			t.Errorf("wrong error. expected=%q, got=%q", test.expected, errObj.Inspect())
+		}
+	}
+}
+
+func TestStringIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"hello"[0]`, "h"},
+		{`"hello"[4]`, "o"},
+		{`"안녕하세요"[1]`, "녕"},
+		{`let s = "가\u{B098}다"; s[1]`, "나"},
+		{`"hello"[5]`, nil},
+		{`""[0]`, nil},
+		{`"hello"[-1]`, "o"},
+		{`"안녕하세요"[-5]`, "안"},
+		{`"hello"[-6]`, nil},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		expected, ok := test.expected.(string)
+		if !ok {
+			testNullObject(t, evaluated)
+			continue
+		}
+
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("object is not String. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+		if str.Value != expected {
+			t.Errorf("String has wrong value. expected=%q, got=%q", expected, str.Value)
+		}
+	}
+}
+
+func TestLogicalShortCircuit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		// 우측이 평가되면 identifier not found 에러가 발생함
+		{"false && undefinedName", false},
+		{"true || undefinedName", true},
+		{"1 > 2 && 1 / 0", false},
+	}
+
+	for _, test := range tests {
+		testBooleanObject(t, testEval(test.input), test.expected)
+	}
+
+	evaluated := testEval("true && undefinedName")
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+	}
+	if errObj.Message != "identifier not found: undefinedName" {
+		t.Errorf("wrong error message. got=%q", errObj.Message)
+	}
+}
+
+func TestDestructuringLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let [a, b] = [1, 2]; a * 10 + b;", 12},
+		{"let [a, b, c] = [1, 2]; c;", nil},
+		{"let [first, ...rest] = [1, 2, 3]; first + len(rest);", 3},
+		{"let [first, ...rest] = [1, 2, 3]; rest[1];", 3},
+		{"let [a, b, ...rest] = [1]; len(rest);", 0},
+		{"let [...all] = [4, 5]; all[0] + all[1];", 9},
+		{`let {name, age} = {"name": "Kim", "age": 30}; age;`, 30},
+		{`let {name, email} = {"name": "Kim"}; email;`, nil},
+		// 해시 패턴은 문자열 키만 찾음
+		{`let {x} = {1: 10}; x;`, nil},
+		{"let swap = fn(pair) { let [a, b] = pair; [b, a] }; let [x, y] = swap([1, 2]); x;", 2},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		integer, ok := test.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b] = 5;", "cannot destructure INTEGER as ARRAY"},
+		{`let [a] = {"a": 1};`, "cannot destructure HASH as ARRAY"},
+		{"let {name} = [1, 2];", "cannot destructure ARRAY as HASH"},
+		{"let {name} = foo;", "identifier not found: foo"},
+		{"let [a, 1] = [1, 2];", "value [1, 2] does not match pattern [a, 1]"},
+	}
+
+	for _, test := range errorTests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%q - no error object returned. got=%T(%+v)", test.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != test.expected {
+			t.Errorf("%q - wrong error message. expected=%q, got=%q", test.input, test.expected, errObj.Message)
+		}
+	}
+}
+
+func TestMatchExpressions(t *testing.T) {
+	describe := `
+	let describe = fn(v) {
+		match (v) {
+			0 => "zero",
+			-1 => "minus one",
+			1.5 => "one and a half",
+			"hi" => "greeting",
+			true => "yes",
+			[] => "empty",
+			[x] => "one: ${x}",
+			[x, y] => "two: ${x + y}",
+			[first, ...rest] => "many: ${first} and ${len(rest)} more",
+			{"kind": "circle", r} => "circle ${r}",
+			{"kind": "rect", w, h} if w == h => "square ${w}",
+			{"kind": "rect", w, h} => "rect ${w}x${h}",
+			n if n == 1000 => "big",
+			_ => "other",
+		}
+	};
+	`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"describe(0)", "zero"},
+		{"describe(-1)", "minus one"},
+		{"describe(1.5)", "one and a half"},
+		{"describe(0.0)", "zero"},
+		{`describe("hi")`, "greeting"},
+		{"describe(true)", "yes"},
+		{"describe(false)", "other"},
+		{"describe([])", "empty"},
+		{"describe([7])", "one: 7"},
+		{"describe([1, 2])", "two: 3"},
+		{"describe([1, 2, 3, 4])", "many: 1 and 3 more"},
+		{`describe({"kind": "circle", "r": 3})`, "circle 3"},
+		{`describe({"kind": "rect", "w": 2, "h": 2})`, "square 2"},
+		{`describe({"kind": "rect", "w": 2, "h": 5})`, "rect 2x5"},
+		{`describe({"kind": "rect", "w": 2})`, "other"},
+		{"describe(1000)", "big"},
+		{"describe(5)", "other"},
+		{`describe("5")`, "other"},
+		// 중첩 패턴
+		{`match ([1, [2, 3]]) { [a, [b, c]] => "${a + b + c}" }`, "6"},
+		{`match ({"user": {"name": "Kim"}}) { {user: {name}} => name }`, "Kim"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(describe + test.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("%q - object is not String. got=%T (%+v)", test.input, evaluated, evaluated)
+			continue
+		}
+		if str.Value != test.expected {
+			t.Errorf("%q - String has wrong value. expected=%q, got=%q", test.input, test.expected, str.Value)
+		}
+	}
+
+	// 패턴 변수는 갈래 안에서만 보임
+	evaluated := testEval("let x = 1; match (5) { x => x }; x;")
+	testIntegerObject(t, evaluated, 1)
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"match (3) { 1 => 1, 2 => 2 }", "non-exhaustive match: no pattern matched 3"},
+		{`match ("a") { [x] => x }`, "non-exhaustive match: no pattern matched a"},
+		{"match (1) { n if n + true => 1 }", "type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, test := range errorTests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%q - no error object returned. got=%T(%+v)", test.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != test.expected {
+			t.Errorf("%q - wrong error message. expected=%q, got=%q", test.input, test.expected, errObj.Message)
+		}
+	}
+}
+
+func TestAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let x = 1; x = 5; x;", 5},
+		{"let x = 1; x = 5;", 5},
+		{"let x = 1; let y = 2; x = y = 3; x + y;", 6},
+		{"let x = 10; x += 5; x;", 15},
+		{"let x = 10; x -= 5; x;", 5},
+		{"let x = 10; x *= 5; x;", 50},
+		{"let x = 10; x /= 5; x;", 2},
+		// 대입은 변수가 선언된 환경의 값을 변경함
+		{"let x = 1; let f = fn() { x = 2; }; f(); x;", 2},
+		// 함수 안에서 새로 선언한 변수는 바깥 변수를 가림
+		{"let x = 1; let f = fn() { let x = 5; x = 2; }; f(); x;", 1},
+		{`
+		let newCounter = fn() {
+			let count = 0;
+			fn() { count += 1; };
+		};
+		let counter = newCounter();
+		counter();
+		counter();
+		counter();
+		`, 3},
+	}
+
+	for _, test := range tests {
+		testIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestConstStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"const x = 5; x;", 5},
+		{"const x = 5; let f = fn() { x * 2 }; f();", 10},
+		// 안쪽 환경에서 같은 이름을 선언해서 가리는 것은 허용
+		{"const x = 5; let f = fn() { let x = 1; x += 1; x }; f();", 2},
+		{"const x = 5; let f = fn(x) { x = 1; x }; f(3);", 1},
+		{"const x = 5; x = 6;", errorMessage("cannot assign to constant x")},
+		{"const x = 5; x += 1;", errorMessage("cannot assign to constant x")},
+		{"const x = 5; let f = fn() { x = 1; }; f();", errorMessage("cannot assign to constant x")},
+		{"const x = 5; let x = 6;", errorMessage("cannot redeclare constant x")},
+		{"const x = 5; const x = 6;", errorMessage("cannot redeclare constant x")},
+		{"const x = 5; let [x, y] = [1, 2];", errorMessage("cannot redeclare constant x")},
+		// 함수 선언문은 먼저 바인딩되지만 앞에 있는 const 를 다시 선언한 것으로 봄
+		{"const f = 5; fn f() { 1 }", errorMessage("cannot redeclare constant f")},
+		{"export const f = 5; export fn f() { 1 }", errorMessage("cannot redeclare constant f")},
+		{"fn f() { 1 } const f = 5;", errorMessage("cannot redeclare f as constant")},
+		{"let x = 5; const x = 6;", errorMessage("cannot redeclare x as constant")},
+		// 반복문 본문의 const 는 회차마다 새로 선언됨
+		{"let i = 0; let sum = 0; while (i < 3) { const y = i; sum += y; i += 1; } sum;", 3},
+		{"let sum = 0; for (i in [1, 2, 3]) { const y = i * 2; sum += y; } sum;", 12},
+		{"let i = 0; while (i < 3) { const y = i; y = 1; }", errorMessage("cannot assign to constant y")},
+		// let 은 지금처럼 다시 선언할 수 있음
+		{"let x = 5; let x = 6; x;", 6},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case errorMessage:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("%q - object is not Error. got=%T (%+v)", test.input, evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != string(expected) {
+				t.Errorf("%q - wrong error message. expected=%q, got=%q", test.input, expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let xs = freeze([1, 2]); xs[0];", 1},
+		{"freeze(5);", 5},
+		{"const config = freeze([1]); config = [2];", errorMessage("cannot assign to constant config")},
+		{"freeze(1, 2);", errorMessage("wrong number of arguments. got=2, want=1")},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case errorMessage:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("%q - object is not Error. got=%T (%+v)", test.input, evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != string(expected) {
+				t.Errorf("%q - wrong error message. expected=%q, got=%q", test.input, expected, errObj.Message)
+			}
+		}
+	}
+
+	frozenTests := []struct {
+		input  string
+		frozen bool
+	}{
+		{"freeze([1, 2]);", true},
+		{`freeze({"a": 1});`, true},
+		// 안쪽의 배열, 해시까지 얼림
+		{`freeze({"a": [1, {"b": 2}], "c": {"d": [3]}});`, true},
+		// 같은 값을 참조하는 모든 변수에서 얼어 있음
+		{"let a = [[1]]; let b = a; freeze(a); b;", true},
+		// 얼린 배열에서 만든 새 배열은 얼어 있지 않음
+		{"push(freeze([1]), 2);", false},
+		{"[1, 2];", false},
+	}
+
+	for _, test := range frozenTests {
+		evaluated := testEval(test.input)
+		if isError(evaluated) {
+			t.Errorf("%q - unexpected error: %s", test.input, evaluated.Inspect())
+			continue
+		}
+		testFrozenObject(t, test.input, evaluated, test.frozen)
+	}
+}
+
+// 배열, 해시와 그 안쪽의 배열, 해시가 모두 expected 만큼 얼어 있는지 확인
+func testFrozenObject(t *testing.T, input string, obj object.Object, expected bool) {
+	t.Helper()
+
+	switch obj := obj.(type) {
+	case *object.Array:
+		if obj.Frozen != expected {
+			t.Errorf("%q - array Frozen wrong. expected=%t, got=%t (%s)", input, expected, obj.Frozen, obj.Inspect())
+		}
+		for _, element := range obj.Elements {
+			testFrozenObject(t, input, element, expected)
+		}
+	case *object.Hash:
+		if obj.Frozen != expected {
+			t.Errorf("%q - hash Frozen wrong. expected=%t, got=%t (%s)", input, expected, obj.Frozen, obj.Inspect())
+		}
+		for _, pair := range obj.Pairs {
+			testFrozenObject(t, input, pair.Key, expected)
+			testFrozenObject(t, input, pair.Value, expected)
+		}
+	}
+}
+
+func TestWhileStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let i = 0; while (i < 10) { i += 1; } i;", 10},
+		{"let i = 0; while (false) { i += 1; } i;", 0},
+		{"while (false) { 1; }", nil},
+		{"let i = 0; while (true) { i += 1; if (i == 5) { break; } } i;", 5},
+		// 짝수만 더함
+		{`
+		let i = 0;
+		let sum = 0;
+		while (i < 10) {
+			i += 1;
+			if (i % 2 == 1) { continue; }
+			sum += i;
+		}
+		sum;
+		`, 30},
+		// break는 가장 가까운 반복문만 빠져나감
+		{`
+		let i = 0;
+		let count = 0;
+		while (i < 3) {
+			i += 1;
+			let j = 0;
+			while (true) {
+				j += 1;
+				if (j > i) { break; }
+				count += 1;
+			}
+		}
+		count;
+		`, 6},
+		// 반복문 안의 return은 함수를 빠져나감
+		{`
+		let find = fn(n) {
+			let i = 0;
+			while (true) {
+				if (i * i >= n) { return i; }
+				i += 1;
+			}
+		};
+		find(50);
+		`, 8},
+		// 값 자리에서 만난 break, continue 는 값으로 바인딩되지 않고 반복문까지 전달됨
+		{"let i = 0; let n = 0; while (i < 3) { i += 1; let x = if (true) { break }; n += 1; } i + n;", 1},
+		{"let i = 0; let n = 0; while (i < 3) { i += 1; let x = if (true) { continue }; n += 1; } i + n;", 3},
+		{"let i = 0; while (i < 3) { i += 1; [1, if (true) { break }]; } i;", 1},
+		{`let i = 0; while (i < 3) { i += 1; {"k": if (true) { break }}; } i;`, 1},
+		{"let i = 0; while (i < 3) { i += 1; puts(if (true) { break }); } i;", 1},
+		{"let i = 0; while (i < 3) { i += 1; 1 + if (true) { break } else { 2 }; } i;", 1},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		integer, ok := test.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestTryExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`try { 1; } catch (e) { 2; }`, 1},
+		{`try { throw "boom"; } catch (e) { 2; }`, 2},
+		// 런타임 에러도 잡을 수 있음
+		{`try { {}[[]]; } catch (e) { e["message"]; }`, "unusable as hash key: ARRAY"},
+		{`try { {}[[]]; } catch (e) { e["kind"]; }`, "RuntimeError"},
+		{`try { throw error("boom", {"code": 42}); } catch (e) { e["data"]["code"]; }`, 42},
+		{`try { throw error("boom"); } catch (e) { e["kind"] + ": " + e["message"]; }`, "Error: boom"},
+		{`try { throw 7; } catch (e) { e["data"]; }`, 7},
+		{`try { throw error("boom"); } catch (e) { e["data"]; }`, nil},
+		// 에러가 던져진 위치
+		{"let f = fn() {\n  throw error(\"boom\");\n};\ntry { f(); } catch (e) { e[\"position\"]; }", "2:3"},
+		{"try {\n  1 + true;\n} catch (e) { [e[\"line\"], e[\"column\"]]; }", []int{2, 3}},
+		// 다시 던지면 처음 위치가 유지됨
+		{"let e = try { throw \"a\"; } catch (e) { e; };\ntry { throw e; } catch (e) { e[\"line\"]; }", 1},
+		// catch 파라미터는 catch 블록 안에서만 보임
+		{`let e = 1; try { throw "x"; } catch (e) { 0; } e;`, 1},
+		// finally 는 항상 실행됨
+		{`let n = 0; try { n += 1; } finally { n += 10; } n;`, 11},
+		{`let n = 0; try { throw "x"; } catch (e) { n += 1; } finally { n += 10; } n;`, 11},
+		{`let f = fn() { try { return 1; } finally { 2; } }; f();`, 1},
+		{`let f = fn() { try { return 1; } finally { return 2; } }; f();`, 2},
+		{`let n = 0; let f = fn() { try { throw "x"; } finally { n = 5; } }; try { f(); } catch (e) { n; }`, 5},
+		// catch 없이 finally 만 있으면 에러는 그대로 전파됨
+		{`try { throw "boom"; } finally { 1; }`, errorMessage("boom")},
+		{`try { throw "a"; } catch (e) { throw e["message"] + "b"; }`, errorMessage("ab")},
+		{`throw error("boom");`, errorMessage("boom")},
+		{`error(1)`, errorMessage("argument to error must be STRING, got INTEGER")},
+		// 반복문 안에서 break, continue 도 그대로 전달됨
+		{`let i = 0; while (true) { try { i += 1; if (i == 3) { break; } } finally { } } i;`, 3},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("%q - object is not String. got=%T (%+v)", test.input, evaluated, evaluated)
+				continue
+			}
+			if str.Value != expected {
+				t.Errorf("%q - wrong value. expected=%q, got=%q", test.input, expected, str.Value)
+			}
+		case []int:
+			array, ok := evaluated.(*object.Array)
+			if !ok || len(array.Elements) != len(expected) {
+				t.Errorf("%q - wrong array. got=%T (%+v)", test.input, evaluated, evaluated)
+				continue
+			}
+			for i, expectedElement := range expected {
+				testIntegerObject(t, array.Elements[i], int64(expectedElement))
+			}
+		case errorMessage:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("%q - object is not Error. got=%T (%+v)", test.input, evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != string(expected) {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestErrorValueInspect(t *testing.T) {
+	evaluated := testEval("try {\n  throw error(\"boom\");\n} catch (e) { e; }")
+
+	errorValue, ok := evaluated.(*object.ErrorValue)
+	if !ok {
+		t.Fatalf("object is not ErrorValue. got=%T (%+v)", evaluated, evaluated)
+	}
+	if errorValue.Inspect() != "Error: boom (at 2:3)" {
+		t.Errorf("wrong Inspect(). got=%q", errorValue.Inspect())
+	}
+}
+
+func TestForInStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let s = ""; for (x in [1, 2, 3]) { s = s + "${x}"; } s;`, "123"},
+		{`let s = ""; for (i, x in ["a", "b"]) { s = s + "${i}${x}"; } s;`, "0a1b"},
+		{`let s = ""; for (c in "한글!") { s = c + s; } s;`, "!글한"},
+		{`let s = ""; for (k, v in {"b": 2, "a": 1, "c": 3}) { s = s + "${k}=${v} "; } s;`, "a=1 b=2 c=3 "},
+		{`let s = ""; for (k in {3: "x", 1: "y", 20: "z"}) { s = s + "${k},"; } s;`, "1,3,20,"},
+		{`let s = ""; for (i in range(5)) { if (i == 1) { continue; } if (i == 4) { break; } s = s + "${i}"; } s;`, "023"},
+		// 반복 변수는 회차마다 새 환경에 바인딩되고 반복문 밖에서는 보이지 않음
+		{`let x = "outer"; for (x in ["inner"]) { x; } x;`, "outer"},
+		// 클로저는 각 회차의 값을 캡처함
+		{`
+		let fns = [];
+		for (x in ["a", "b", "c"]) {
+			fns = push(fns, fn() { x });
+		}
+		fns[0]() + fns[1]() + fns[2]();
+		`, "abc"},
+		{`let f = fn() { for (x in ["a", "b"]) { return x; } }; f();`, "a"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("%q - object is not String. got=%T (%+v)", test.input, evaluated, evaluated)
+			continue
+		}
+		if str.Value != test.expected {
+			t.Errorf("%q - String has wrong value. expected=%q, got=%q", test.input, test.expected, str.Value)
+		}
+	}
+
+	evaluated := testEval("for (x in 5) { x; }")
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+	}
+	if errObj.Message != "iteration not supported: INTEGER" {
+		t.Errorf("wrong error message. got=%q", errObj.Message)
+	}
+}
+
+func TestStringInterpolation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let name = "Monkey"; "Hello ${name}!"`, "Hello Monkey!"},
+		{`let count = 3; "you have ${count} items"`, "you have 3 items"},
+		{`let user = {"name": "kim"}; "${user["name"]}: ${1.5 * 2}, ${[1, 2]}, ${true}"`, "kim: 3.0, [1, 2], true"},
+		{`"outer ${"inner ${1 + 1}"}"`, "outer inner 2"},
+		{`"no interpolation \${x}"`, "no interpolation ${x}"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("object is not String. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+		if str.Value != test.expected {
+			t.Errorf("String has wrong value. expected=%q, got=%q", test.expected, str.Value)
+		}
+	}
+
+	evaluated := testEval(`"a ${missing} b"`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+	}
+	if errObj.Message != "identifier not found: missing" {
+		t.Errorf("wrong error message. got=%q", errObj.Message)
+	}
+}
@@ -0,0 +1,154 @@
+package evaluator
+
+import (
+	"fmt"
+	"interpreter-go/ast"
+	"interpreter-go/object"
+)
+
+// DefineMacros : 최상위의 let name = macro(...) { ... } 구문을 env에 매크로로 등록하고 프로그램에서 제거
+func DefineMacros(program *ast.Program, env *object.Environment) {
+	definitions := []int{}
+
+	for i, statement := range program.Statements {
+		if isMacroDefinition(statement) {
+			addMacro(statement, env)
+			definitions = append(definitions, i)
+		}
+	}
+
+	// 뒤에서부터 지워야 앞쪽 인덱스가 밀리지 않음
+	for i := len(definitions) - 1; i >= 0; i-- {
+		index := definitions[i]
+		program.Statements = append(program.Statements[:index], program.Statements[index+1:]...)
+	}
+}
+
+func isMacroDefinition(node ast.Statement) bool {
+	letStatement, ok := node.(*ast.LetStatement)
+	if !ok || letStatement.Name == nil {
+		return false
+	}
+
+	_, ok = letStatement.Value.(*ast.MacroLiteral)
+	return ok
+}
+
+func addMacro(statement ast.Statement, env *object.Environment) {
+	letStatement := statement.(*ast.LetStatement)
+	macroLiteral := letStatement.Value.(*ast.MacroLiteral)
+
+	macro := &object.Macro{
+		Parameters: macroLiteral.Parameters,
+		Body:       macroLiteral.Body,
+		Env:        env,
+	}
+
+	env.Set(letStatement.Name.Value, macro)
+}
+
+// ExpandMacros : 매크로 호출을 찾아서 인자를 평가하지 않은 채(quote) 매크로 본문을 평가하고,
+// 호출한 자리를 매크로가 리턴한 AST로 바꿈
+// 매크로가 리턴한 AST 안의 매크로 호출도 다시 확장함 (maxMacroDepth 단계까지)
+// 매크로가 Quote를 리턴하지 않거나 매크로 본문에서 에러가 나면 첫번째 에러를 리턴
+func ExpandMacros(program ast.Node, env *object.Environment) (ast.Node, error) {
+	return expandMacros(program, env, 0)
+}
+
+// 매크로가 자기 자신을 끝없이 확장하는 경우를 막기 위한 확장 단계 제한
+const maxMacroDepth = 100
+
+func expandMacros(program ast.Node, env *object.Environment, depth int) (ast.Node, error) {
+	var err error
+
+	expanded := ast.Modify(program, func(node ast.Node) ast.Node {
+		if err != nil {
+			return node
+		}
+
+		call, ok := node.(*ast.CallExpression)
+		if !ok {
+			return node
+		}
+
+		macro, ok := isMacroCall(call, env)
+		if !ok {
+			return node
+		}
+
+		if depth >= maxMacroDepth {
+			err = fmt.Errorf("%s: macro expansion too deep: %s", call.Pos(), call.Function)
+			return node
+		}
+
+		if len(call.Arguments) != len(macro.Parameters) {
+			err = fmt.Errorf("%s: wrong number of arguments to macro %s: want %d, got %d",
+				call.Pos(), call.Function, len(macro.Parameters), len(call.Arguments))
+			return node
+		}
+
+		evalEnv := extendMacroEnv(macro, quoteArgs(call))
+		evaluated := Eval(macro.Body, evalEnv)
+
+		switch evaluated := evaluated.(type) {
+		case *object.Quote:
+			var result ast.Node
+			result, err = expandMacros(evaluated.Node, env, depth+1)
+			if err != nil {
+				return node
+			}
+			return result
+		case *object.Error:
+			err = fmt.Errorf("%s: %s", evaluated.Pos, evaluated.Message)
+		default:
+			err = fmt.Errorf("%s: macro %s must return a quoted AST node, got %s",
+				call.Pos(), call.Function, typeOf(evaluated))
+		}
+		return node
+	})
+
+	return expanded, err
+}
+
+func isMacroCall(call *ast.CallExpression, env *object.Environment) (*object.Macro, bool) {
+	identifier, ok := call.Function.(*ast.Identifier)
+	if !ok {
+		return nil, false
+	}
+
+	obj, ok := env.Get(identifier.Value)
+	if !ok {
+		return nil, false
+	}
+
+	macro, ok := obj.(*object.Macro)
+	return macro, ok
+}
+
+func quoteArgs(call *ast.CallExpression) []*object.Quote {
+	args := []*object.Quote{}
+
+	for _, a := range call.Arguments {
+		args = append(args, &object.Quote{Node: a})
+	}
+
+	return args
+}
+
+func extendMacroEnv(macro *object.Macro, args []*object.Quote) *object.Environment {
+	extended := object.NewEnclosedEnvironment(macro.Env)
+
+	for i, param := range macro.Parameters {
+		extended.Set(param.Value, args[i])
+	}
+
+	return extended
+}
+
+// 매크로 본문이 아무 값도 만들지 않은 경우도 에러 메시지에 쓸 수 있도록 타입명을 구함
+func typeOf(obj object.Object) object.ObjectType {
+	if obj == nil {
+		return object.NULL_OBJ
+	}
+	return obj.Type()
+}
@@ -0,0 +1,256 @@
+package evaluator
+
+import (
+	"interpreter-go/ast"
+	"interpreter-go/lexer"
+	"interpreter-go/object"
+	"interpreter-go/parser"
+	"testing"
+)
+
+func TestDefineMacros(t *testing.T) {
+	input := `
+	let number = 1;
+	let function = fn(x, y) { x + y };
+	let mymacro = macro(x, y) { x + y; };
+	`
+
+	env := object.NewEnvironment()
+	program := testParseProgram(input)
+
+	DefineMacros(program, env)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("Wrong number of statements. got=%d", len(program.Statements))
+	}
+
+	if _, ok := env.Get("number"); ok {
+		t.Fatalf("number should not be defined")
+	}
+	if _, ok := env.Get("function"); ok {
+		t.Fatalf("function should not be defined")
+	}
+
+	obj, ok := env.Get("mymacro")
+	if !ok {
+		t.Fatalf("macro not in environment.")
+	}
+
+	macro, ok := obj.(*object.Macro)
+	if !ok {
+		t.Fatalf("object is not Macro. got=%T (%+v)", obj, obj)
+	}
+
+	if len(macro.Parameters) != 2 {
+		t.Fatalf("Wrong number of macro parameters. got=%d", len(macro.Parameters))
+	}
+
+	if macro.Parameters[0].String() != "x" {
+		t.Fatalf("parameter is not 'x'. got=%q", macro.Parameters[0])
+	}
+	if macro.Parameters[1].String() != "y" {
+		t.Fatalf("parameter is not 'y'. got=%q", macro.Parameters[1])
+	}
+
+	expectedBody := "(x + y)"
+
+	if macro.Body.String() != expectedBody {
+		t.Fatalf("body is not %q. got=%q", expectedBody, macro.Body.String())
+	}
+}
+
+func TestExpandMacros(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`
+			let infixExpression = macro() { quote(1 + 2); };
+
+			infixExpression();
+			`,
+			`(1 + 2)`,
+		},
+		{
+			`
+			let reverse = macro(a, b) { quote(unquote(b) - unquote(a)); };
+
+			reverse(2 + 2, 10 - 5);
+			`,
+			`(10 - 5) - (2 + 2)`,
+		},
+		{
+			`
+			let unless = macro(condition, consequence, alternative) {
+				quote(if (!(unquote(condition))) {
+					unquote(consequence);
+				} else {
+					unquote(alternative);
+				});
+			};
+
+			unless(10 > 5, puts("not greater"), puts("greater"));
+			`,
+			`if (!(10 > 5)) { puts("not greater") } else { puts("greater") }`,
+		},
+		// 함수 본문 안의 매크로 호출도 확장됨
+		{
+			`
+			let double = macro(x) { quote(unquote(x) * 2); };
+
+			let f = fn(n) { double(n + 1) };
+			`,
+			`let f = fn(n) { (n + 1) * 2 };`,
+		},
+		// 같은 매크로를 다른 인자로 여러 번 호출
+		{
+			`
+			let double = macro(x) { quote(unquote(x) * 2); };
+
+			[double(3), double(10)];
+			`,
+			`[(3 * 2), (10 * 2)];`,
+		},
+		{
+			`
+			let unless = macro(condition, consequence) {
+				quote(if (!(unquote(condition))) { unquote(consequence) });
+			};
+
+			unless(1 > 2, "first");
+			unless(2 > 3, "second");
+			`,
+			`if (!(1 > 2)) { "first" }; if (!(2 > 3)) { "second" };`,
+		},
+		// 매크로가 리턴한 AST 안의 매크로 호출도 확장됨
+		{
+			`
+			let m = macro(x) { quote(unquote(x) * 2); };
+			let n = macro(x) { quote(m(unquote(x))); };
+
+			n(4);
+			`,
+			`(4 * 2)`,
+		},
+	}
+
+	for _, tt := range tests {
+		expected := testParseProgram(tt.expected)
+		program := testParseProgram(tt.input)
+
+		env := object.NewEnvironment()
+		DefineMacros(program, env)
+		expanded, err := ExpandMacros(program, env)
+		if err != nil {
+			t.Fatalf("ExpandMacros returned error: %s", err)
+		}
+
+		if expanded.String() != expected.String() {
+			t.Errorf("not equal. want=%q, got=%q", expected.String(), expanded.String())
+		}
+	}
+}
+
+func TestExpandMacrosErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"let m = macro(x) { x };\nm(1, 2);",
+			"2:1: wrong number of arguments to macro m: want 1, got 2",
+		},
+		{
+			"let m = macro() { 1 };\nm();",
+			"2:1: macro m must return a quoted AST node, got INTEGER",
+		},
+		{
+			"let m = macro() {\n  1 + true\n};\nm();",
+			"2:3: type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			"let m = macro(x) { quote(m(unquote(x))) };\nm(1);",
+			"1:26: macro expansion too deep: m",
+		},
+	}
+
+	for _, tt := range tests {
+		program := testParseProgram(tt.input)
+
+		env := object.NewEnvironment()
+		DefineMacros(program, env)
+		_, err := ExpandMacros(program, env)
+		if err == nil {
+			t.Errorf("%q - expected error", tt.input)
+			continue
+		}
+
+		if err.Error() != tt.expected {
+			t.Errorf("wrong error. want=%q, got=%q", tt.expected, err.Error())
+		}
+	}
+}
+
+func TestMacros(t *testing.T) {
+	input := `
+	let unless = macro(condition, consequence, alternative) {
+		quote(if (!(unquote(condition))) {
+			unquote(consequence);
+		} else {
+			unquote(alternative);
+		});
+	};
+
+	let assert = macro(condition, message) {
+		quote(if (!(unquote(condition))) { throw error(unquote(message)); });
+	};
+
+	let check = fn(n) {
+		assert(n > 0, "must be positive");
+		unless(n > 10, "small", "big")
+	};
+
+	[check(5), check(50), try { check(-1) } catch (e) { e["message"] }];
+	`
+
+	program := testParseProgram(input)
+	env := object.NewEnvironment()
+	DefineMacros(program, env)
+	expanded, err := ExpandMacros(program, env)
+	if err != nil {
+		t.Fatalf("ExpandMacros returned error: %s", err)
+	}
+
+	evaluated := Eval(expanded, object.NewEnvironment())
+	array, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	expected := []string{"small", "big", "must be positive"}
+	for i, want := range expected {
+		str, ok := array.Elements[i].(*object.String)
+		if !ok || str.Value != want {
+			t.Errorf("elements[%d] wrong. want=%q, got=%+v", i, want, array.Elements[i])
+		}
+	}
+}
+
+func TestMacroLiteralOutsideDefinition(t *testing.T) {
+	evaluated := testEval("let f = fn() { macro(x) { x } }; f();")
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+	}
+	if errObj.Message != "macro must be defined by a top-level let statement" {
+		t.Errorf("wrong error message. got=%q", errObj.Message)
+	}
+}
+
+func testParseProgram(input string) *ast.Program {
+	l := lexer.New(input)
+	p := parser.New(l)
+	return p.ParseProgram()
+}
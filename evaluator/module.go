@@ -0,0 +1,174 @@
+package evaluator
+
+import (
+	"interpreter-go/ast"
+	"interpreter-go/lexer"
+	"interpreter-go/object"
+	"interpreter-go/parser"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ModuleLoader : import 한 모듈을 찾아서 평가하고 결과를 캐시
+type ModuleLoader struct {
+	SearchPaths []string // ./ 혹은 ../ 로 시작하지 않는 경로를 찾을 디렉터리 목록 (앞에서부터 찾음)
+
+	cache   map[string]*object.Module // 절대 경로별로 평가가 끝난 모듈
+	loading []string                  // 평가 중인 모듈의 절대 경로 (순환 import 감지)
+}
+
+func NewModuleLoader(searchPaths ...string) *ModuleLoader {
+	return &ModuleLoader{
+		SearchPaths: searchPaths,
+		cache:       make(map[string]*object.Module),
+	}
+}
+
+// Modules : import 구문이 사용하는 모듈 로더 (기본 검색 경로는 현재 디렉터리)
+var Modules = NewModuleLoader(".")
+
+func evalImportStatement(is *ast.ImportStatement, env *object.Environment) object.Object {
+	module := Modules.Load(is.Path.Value, is.Pos().Filename)
+	if isError(module) {
+		return module
+	}
+
+	name := strings.TrimSuffix(filepath.Base(is.Path.Value), filepath.Ext(is.Path.Value))
+	if is.Alias != nil {
+		name = is.Alias.Value
+	}
+	if err := declare(env, name, module, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 모듈이 export 한 이름을 찾음
+func evalMemberExpression(me *ast.MemberExpression, env *object.Environment) object.Object {
+	obj := Eval(me.Object, env)
+	if isAbrupt(obj) {
+		return obj
+	}
+
+	module, ok := obj.(*object.Module)
+	if !ok {
+		return newError("member access not supported: %s", obj.Type())
+	}
+
+	val, ok := module.Exports[me.Property.Value]
+	if !ok {
+		return newError("module %s has no export %s", module.Name, me.Property.Value)
+	}
+	return val
+}
+
+// Load : path 의 모듈을 평가해서 리턴, 이미 평가한 모듈이면 캐시된 모듈을 리턴
+// from 은 import 구문이 있는 파일명이고 ./ 혹은 ../ 로 시작하는 경로는 그 파일의 디렉터리에서 찾음
+func (ml *ModuleLoader) Load(path, from string) object.Object {
+	filename, ok := ml.resolve(path, from)
+	if !ok {
+		return newError("module not found: %s", path)
+	}
+
+	key, err := filepath.Abs(filename)
+	if err != nil {
+		return newError("module not found: %s", path)
+	}
+
+	if module, ok := ml.cache[key]; ok {
+		return module
+	}
+
+	for i, loading := range ml.loading {
+		if loading == key {
+			cycle := []string{}
+			for _, k := range ml.loading[i:] {
+				cycle = append(cycle, ml.displayName(k))
+			}
+			cycle = append(cycle, ml.displayName(key))
+			return newError("import cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	ml.loading = append(ml.loading, key)
+	defer func() { ml.loading = ml.loading[:len(ml.loading)-1] }()
+
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		return newError("cannot read module %s: %s", path, err)
+	}
+
+	exports, errObj := evalModule(filename, string(source))
+	if errObj != nil {
+		return errObj
+	}
+
+	module := &object.Module{Name: path, Exports: exports}
+	ml.cache[key] = module
+	return module
+}
+
+// 검색 순서 : 절대 경로 -> import 한 파일 기준의 상대 경로 -> 검색 경로
+func (ml *ModuleLoader) resolve(path, from string) (string, bool) {
+	var candidates []string
+
+	switch {
+	case filepath.IsAbs(path):
+		candidates = []string{path}
+	case strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../"):
+		candidates = []string{filepath.Join(filepath.Dir(from), path)}
+	default:
+		for _, dir := range ml.SearchPaths {
+			candidates = append(candidates, filepath.Join(dir, path))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// 에러 메시지에 쓸 경로 (현재 디렉터리 기준의 상대 경로로 표시할 수 있으면 상대 경로)
+func (ml *ModuleLoader) displayName(key string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, key); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return key
+}
+
+// 모듈 소스를 새 환경에서 파싱, 매크로 확장, 평가한 후 export 한 이름과 값을 리턴
+func evalModule(filename, source string) (map[string]object.Object, *object.Error) {
+	p := parser.New(lexer.NewFile(filename, source))
+	program := p.ParseProgram()
+	if errors := p.Errors(); len(errors) != 0 {
+		return nil, newError("cannot parse module: %s", errors[0])
+	}
+
+	macroEnv := object.NewEnvironment()
+	DefineMacros(program, macroEnv)
+	if _, err := ExpandMacros(program, macroEnv); err != nil {
+		return nil, newError("cannot expand macros in module: %s", err)
+	}
+
+	env := object.NewEnvironment()
+	if result := Eval(program, env); isError(result) {
+		return nil, result.(*object.Error)
+	}
+
+	exports := make(map[string]object.Object)
+	for _, statement := range program.Statements {
+		if es, ok := statement.(*ast.ExportStatement); ok {
+			if val, ok := env.Get(es.Name.Value); ok {
+				exports[es.Name.Value] = val
+			}
+		}
+	}
+	return exports, nil
+}
@@ -0,0 +1,171 @@
+package evaluator
+
+import (
+	"interpreter-go/lexer"
+	"interpreter-go/object"
+	"interpreter-go/parser"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 임시 디렉터리에 파일들을 만들고 그 디렉터리를 검색 경로로 쓰는 모듈 로더로 바꿈
+func setupModules(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, source := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loader := Modules
+	Modules = NewModuleLoader(dir)
+	t.Cleanup(func() { Modules = loader })
+
+	return dir
+}
+
+func TestImportStatements(t *testing.T) {
+	setupModules(t, map[string]string{
+		"lib/strings.mk": `
+		let count = 0;
+		export let greeting = "hello";
+		export const version = 2;
+		export fn shout(s) { s + "!" }
+		export let twice = fn(s) { shout(s) + shout(s) };
+		let secret = 42;
+		`,
+		"lib/math.mk": `
+		import "./strings.mk" as s;
+		export let double = |x| x * 2;
+		export let greet = fn() { s.greeting };
+		`,
+	})
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`import "lib/strings.mk" as s; s.greeting`, "hello"},
+		{`import "lib/strings.mk" as s; s.shout("hi")`, "hi!"},
+		{`import "lib/strings.mk" as s; s.twice("a")`, "a!a!"},
+		{`import "lib/strings.mk" as s; s.version`, 2},
+		// as 를 생략하면 파일명이 이름이 됨
+		{`import "lib/strings.mk"; strings.greeting`, "hello"},
+		{`import "lib/math.mk" as m; 21 |> m.double`, 42},
+		// ./ 로 시작하는 경로는 import 한 파일 기준
+		{`import "lib/math.mk" as m; m.greet()`, "hello"},
+		{`import "lib/strings.mk" as s; s.secret`, errorMessage("module lib/strings.mk has no export secret")},
+		{`import "lib/missing.mk" as s;`, errorMessage("module not found: lib/missing.mk")},
+		{`let h = {}; h.x`, errorMessage("member access not supported: HASH")},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("%q - object is not String. got=%T (%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+			if str.Value != expected {
+				t.Errorf("%q - wrong value. expected=%q, got=%q", tt.input, expected, str.Value)
+			}
+		case errorMessage:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("%q - object is not Error. got=%T (%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != string(expected) {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestModuleCaching(t *testing.T) {
+	setupModules(t, map[string]string{
+		"counter.mk": `
+		export let state = {"loaded": true};
+		`,
+	})
+
+	input := `
+	import "counter.mk" as a;
+	import "counter.mk" as b;
+	a.state == b.state;
+	`
+
+	program := parser.New(lexer.New(input)).ParseProgram()
+	env := object.NewEnvironment()
+	Eval(program, env)
+
+	a, _ := env.Get("a")
+	b, _ := env.Get("b")
+	if a != b {
+		t.Errorf("module evaluated twice. a=%p, b=%p", a, b)
+	}
+	if len(Modules.cache) != 1 {
+		t.Errorf("wrong number of cached modules. got=%d", len(Modules.cache))
+	}
+}
+
+func TestModuleErrors(t *testing.T) {
+	dir := setupModules(t, map[string]string{
+		"a.mk":      `import "b.mk"; export let a = 1;`,
+		"b.mk":      `import "c.mk"; export let b = 1;`,
+		"c.mk":      `import "a.mk"; export let c = 1;`,
+		"self.mk":   `import "self.mk";`,
+		"broken.mk": `let = 1;`,
+		"fails.mk":  "let x = 1;\nx + true;",
+	})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import "a.mk";`, "import cycle: " + filepath.Join(dir, "a.mk") + " -> " + filepath.Join(dir, "b.mk") +
+			" -> " + filepath.Join(dir, "c.mk") + " -> " + filepath.Join(dir, "a.mk")},
+		{`import "self.mk";`, "import cycle: " + filepath.Join(dir, "self.mk") + " -> " + filepath.Join(dir, "self.mk")},
+		{`import "broken.mk";`, "cannot parse module: " + filepath.Join(dir, "broken.mk") + ":1:5: Expected next token to be IDENT, got = instead"},
+		{`import "fails.mk";`, "type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%q - object is not Error. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message.\nexpected=%q\ngot=%q", tt.expected, errObj.Message)
+		}
+	}
+
+	// 모듈 안에서 난 에러는 모듈 파일의 위치를 가리킴
+	evaluated := testEval(`import "fails.mk";`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+	}
+	if expected := filepath.Join(dir, "fails.mk") + ":2:1"; errObj.Pos.String() != expected {
+		t.Errorf("wrong error position. expected=%q, got=%q", expected, errObj.Pos.String())
+	}
+
+	// 실패한 모듈은 캐시하지 않음
+	if len(Modules.cache) != 0 {
+		t.Errorf("failed modules should not be cached. got=%d", len(Modules.cache))
+	}
+}
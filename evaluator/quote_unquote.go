@@ -0,0 +1,97 @@
+package evaluator
+
+import (
+	"fmt"
+	"interpreter-go/ast"
+	"interpreter-go/object"
+	"interpreter-go/token"
+)
+
+// quote(expr) : 인자를 평가하지 않고 AST 그대로 감싸서 리턴
+// 단, 안쪽의 unquote(expr) 호출은 평가한 결과를 AST로 바꿔서 끼워넣음
+// 같은 quote 가 함수나 매크로 본문에서 여러 번 평가되므로 복사본에 끼워넣어야 원래 AST가 바뀌지 않음
+func quote(node ast.Node, env *object.Environment) object.Object {
+	node, err := evalUnquoteCalls(ast.Copy(node), env)
+	if err != nil {
+		return err
+	}
+	return &object.Quote{Node: node}
+}
+
+func evalUnquoteCalls(quoted ast.Node, env *object.Environment) (ast.Node, *object.Error) {
+	var err *object.Error
+
+	node := ast.Modify(quoted, func(node ast.Node) ast.Node {
+		if err != nil || !isUnquoteCall(node) {
+			return node
+		}
+
+		call := node.(*ast.CallExpression)
+		if len(call.Arguments) != 1 {
+			err = newError("wrong number of arguments to unquote. got=%d, want=1", len(call.Arguments))
+			err.Pos = call.Pos()
+			return node
+		}
+
+		unquoted := Eval(call.Arguments[0], env)
+		if e, ok := unquoted.(*object.Error); ok {
+			err = e
+			return node
+		}
+
+		converted := convertObjectToASTNode(unquoted, call.Pos())
+		if converted == nil {
+			err = newError("cannot unquote %s", unquoted.Type())
+			err.Pos = call.Pos()
+			return node
+		}
+		return converted
+	})
+
+	return node, err
+}
+
+func isUnquoteCall(node ast.Node) bool {
+	call, ok := node.(*ast.CallExpression)
+	if !ok {
+		return false
+	}
+	return isCallTo(call, "unquote")
+}
+
+// 함수 자리가 주어진 이름의 식별자인 호출식인지 확인 ex) quote(...), unquote(...)
+func isCallTo(call *ast.CallExpression, name string) bool {
+	identifier, ok := call.Function.(*ast.Identifier)
+	return ok && identifier.Value == name
+}
+
+// 평가된 값을 다시 AST 노드로 바꿈 (노드의 위치는 unquote를 호출한 위치)
+// AST로 표현할 수 없는 값이면 nil
+func convertObjectToASTNode(obj object.Object, pos token.Position) ast.Node {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		literal := fmt.Sprintf("%d", obj.Value)
+		return &ast.IntegerLiteral{Token: token.Token{Type: token.INT, Literal: literal, Pos: pos}, Value: obj.Value}
+
+	case *object.Float:
+		return &ast.FloatLiteral{Token: token.Token{Type: token.FLOAT, Literal: obj.Inspect(), Pos: pos}, Value: obj.Value}
+
+	case *object.Boolean:
+		var t token.Token
+		if obj.Value {
+			t = token.Token{Type: token.TRUE, Literal: "true", Pos: pos}
+		} else {
+			t = token.Token{Type: token.FALSE, Literal: "false", Pos: pos}
+		}
+		return &ast.Boolean{Token: t, Value: obj.Value}
+
+	case *object.String:
+		return &ast.StringLiteral{Token: token.Token{Type: token.STRING, Literal: obj.Value, Pos: pos}, Value: obj.Value}
+
+	case *object.Quote:
+		return obj.Node
+
+	default:
+		return nil
+	}
+}
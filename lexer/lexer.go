@@ -1,69 +1,290 @@
 package lexer
 
-import "interpreter-go/token"
+import (
+	"bufio"
+	"fmt"
+	"interpreter-go/token"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mode : 렉서 동작 옵션
+type Mode uint
+
+const (
+	ScanComments Mode = 1 << iota // 주석을 건너뛰지 않고 COMMENT 토큰으로 반환
+)
+
+// 문자열 보간(${...}) 하나의 상태
+type interpolation struct {
+	start  token.Position // 문자열을 연 따옴표 위치
+	braces int            // 보간식 안에서 열려있는 중괄호 개수
+}
+
+// 미리 읽어둔 문자
+type lookaheadChar struct {
+	ch    rune
+	width int // UTF-8 바이트 길이
+}
 
 type Lexer struct {
-	input        string
-	position     int  // 입력에서 현재 위치 (현재 문자의 주소)
-	readPosition int  // 입력에서 현재 읽는 위치 (다음 문자의 주소)
-	ch           byte // 현자 조사하는 문자 (position에 해당하는 문자)
+	reader    io.RuneReader   // 입력을 한 문자씩 디코딩
+	lookahead []lookaheadChar // peek 하느라 미리 읽어둔 문자 (최대 2개)
+	atEOF     bool            // 입력의 끝에 도달했는지 여부
+
+	text []byte // 현재 토큰의 시작부터 지금까지 지나간 입력 (토큰 리터럴 용)
+
+	filename     string // 에러 메시지에 표시할 파일명
+	position     int    // 입력에서 현재 위치 (현재 문자의 주소)
+	readPosition int    // 입력에서 현재 읽는 위치 (다음 문자의 주소)
+	ch           rune   // 현자 조사하는 문자 (position에 해당하는 문자)
+	line         int    // 현재 문자의 줄 번호
+	column       int    // 현재 문자의 열 번호 (문자 단위)
+	mode         Mode
+	errors       []string // 렉싱 중 발견한 에러
+
+	interpolations []interpolation // 현재 열려있는 문자열 보간 (중첩 가능)
 }
 
 // New 생성자
 func New(input string) *Lexer {
-	lexer := &Lexer{input: input}
+	return NewFile("", input)
+}
+
+// NewFile : 토큰 위치에 파일명을 함께 기록하는 생성자
+func NewFile(filename, input string) *Lexer {
+	return NewFileReader(filename, strings.NewReader(input))
+}
+
+// NewReader : 입력 전체를 메모리에 올리지 않고 io.Reader에서 필요한 만큼만 읽는 생성자
+func NewReader(r io.Reader) *Lexer {
+	return NewFileReader("", r)
+}
+
+// NewFileReader : NewReader에 파일명을 함께 기록하는 생성자
+func NewFileReader(filename string, r io.Reader) *Lexer {
+	reader, ok := r.(io.RuneReader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+
+	lexer := &Lexer{reader: reader, filename: filename, line: 1, column: 1}
 	lexer.readChar() // position, readPosition, char 초기화
 	return lexer
 }
 
+// SetMode : 렉서 동작 옵션 변경 (첫 토큰을 읽기 전에 호출)
+func (lexer *Lexer) SetMode(mode Mode) {
+	lexer.mode = mode
+}
+
+// Errors : 렉싱 중 발견한 에러 목록
+func (lexer *Lexer) Errors() []string {
+	return lexer.errors
+}
+
+// 에러 메시지 앞에 위치를 붙여서 저장
+func (lexer *Lexer) addError(pos token.Position, format string, a ...interface{}) {
+	msg := pos.String() + ": " + fmt.Sprintf(format, a...)
+	lexer.errors = append(lexer.errors, msg)
+}
+
 func (lexer *Lexer) readChar() {
-	if lexer.readPosition >= len(lexer.input) {
-		lexer.ch = 0
-	} else {
-		lexer.ch = lexer.input[lexer.readPosition]
+	// 이미 입력의 끝에 도달했으면 위치를 더 이상 진행하지 않음
+	if lexer.atEOF {
+		return
+	}
+
+	// 지금 문자를 지나가면서 줄/열 번호 갱신 (최초 호출 시에는 지나간 문자가 없음)
+	if lexer.ch == '\n' {
+		lexer.line++
+		lexer.column = 1
+	} else if lexer.readPosition > 0 {
+		lexer.column++
+	}
+
+	// 지나간 문자는 토큰 리터럴을 만들 수 있도록 기록
+	if lexer.readPosition > 0 {
+		lexer.text = utf8.AppendRune(lexer.text, lexer.ch)
 	}
+
+	// UTF-8 문자 하나의 바이트 길이만큼 진행
 	lexer.position = lexer.readPosition
-	lexer.readPosition += 1
+	if len(lexer.lookahead) > 0 {
+		next := lexer.lookahead[0]
+		lexer.lookahead = lexer.lookahead[1:]
+		lexer.ch = next.ch
+		lexer.readPosition += next.width
+	} else if ch, width := lexer.decodeChar(); width > 0 {
+		lexer.ch = ch
+		lexer.readPosition += width
+	} else {
+		lexer.ch = 0
+		lexer.atEOF = true
+	}
 }
 
-func (lexer *Lexer) NextToken() token.Token {
-	var tok token.Token
+// 입력에서 문자 하나를 디코딩 (입력의 끝이면 width 0)
+func (lexer *Lexer) decodeChar() (rune, int) {
+	if lexer.atEOF {
+		return 0, 0
+	}
+
+	ch, width, err := lexer.reader.ReadRune()
+	if err != nil {
+		if err != io.EOF {
+			lexer.addError(lexer.currentPosition(), "read error: %s", err)
+		}
+		return 0, 0
+	}
+	return ch, width
+}
+
+// n번째 뒤의 문자를 살펴봄 (0이면 바로 다음 문자)
+func (lexer *Lexer) peekCharAt(n int) rune {
+	for len(lexer.lookahead) <= n {
+		ch, width := lexer.decodeChar()
+		if width == 0 {
+			return 0
+		}
+		lexer.lookahead = append(lexer.lookahead, lookaheadChar{ch: ch, width: width})
+	}
+	return lexer.lookahead[n].ch
+}
+
+// 새 토큰을 읽기 전에 기록해둔 입력을 비움
+func (lexer *Lexer) resetText() {
+	lexer.text = lexer.text[:0]
+}
 
+// 현재 문자의 위치를 표시 (textFrom에 넘겨서 그 사이의 입력을 얻음)
+func (lexer *Lexer) textMark() int {
+	return len(lexer.text)
+}
+
+// mark 위치부터 현재 문자 직전까지의 입력
+func (lexer *Lexer) textFrom(mark int) string {
+	return string(lexer.text[mark:])
+}
+
+// 현재 문자의 위치
+func (lexer *Lexer) currentPosition() token.Position {
+	return token.Position{
+		Filename: lexer.filename,
+		Offset:   lexer.position,
+		Line:     lexer.line,
+		Column:   lexer.column,
+	}
+}
+
+func (lexer *Lexer) NextToken() token.Token {
 	lexer.skipWhiteSpace()
 
+	// 주석은 ScanComments 모드가 아니면 공백처럼 건너뜀
+	for lexer.isCommentStart() {
+		lexer.resetText()
+		start := lexer.currentPosition()
+		literal := lexer.readComment()
+		if lexer.mode&ScanComments != 0 {
+			return token.Token{Type: token.COMMENT, Literal: literal, Pos: start, End: lexer.currentPosition()}
+		}
+		lexer.skipWhiteSpace()
+	}
+
+	lexer.resetText()
+	start := lexer.currentPosition()
+	tok := lexer.readToken()
+	tok.Pos = start
+	tok.End = lexer.currentPosition()
+
+	return tok
+}
+
+// 공백 이후의 토큰 하나를 읽음 (위치는 NextToken에서 채움)
+func (lexer *Lexer) readToken() token.Token {
+	var tok token.Token
+
 	switch lexer.ch {
 	case '=':
 		if lexer.peekChar() == '=' {
-			ch := lexer.ch
-			lexer.readChar()
-			literal := string(ch) + string(lexer.ch)
-			tok = token.Token{Type: token.EQ, Literal: literal}
+			tok = lexer.readTwoCharToken(token.EQ)
+		} else if lexer.peekChar() == '>' {
+			tok = lexer.readTwoCharToken(token.ARROW)
 		} else {
 			tok = newToken(token.ASSIGN, lexer.ch)
 		}
 	case '+':
-		tok = newToken(token.PLUS, lexer.ch)
+		if lexer.peekChar() == '=' {
+			tok = lexer.readTwoCharToken(token.PLUS_ASSIGN)
+		} else {
+			tok = newToken(token.PLUS, lexer.ch)
+		}
 	case '-':
-		tok = newToken(token.MINUS, lexer.ch)
+		if lexer.peekChar() == '=' {
+			tok = lexer.readTwoCharToken(token.MINUS_ASSIGN)
+		} else {
+			tok = newToken(token.MINUS, lexer.ch)
+		}
 	case '!':
 		if lexer.peekChar() == '=' {
-			ch := lexer.ch
-			lexer.readChar()
-			literal := string(ch) + string(lexer.ch)
-			tok = token.Token{Type: token.NOT_EQ, Literal: literal}
+			tok = lexer.readTwoCharToken(token.NOT_EQ)
 		} else {
 			tok = newToken(token.BANG, lexer.ch)
 		}
 	case '/':
-		tok = newToken(token.SLASH, lexer.ch)
+		if lexer.peekChar() == '=' {
+			tok = lexer.readTwoCharToken(token.SLASH_ASSIGN)
+		} else {
+			tok = newToken(token.SLASH, lexer.ch)
+		}
 	case '*':
-		tok = newToken(token.ASTERISK, lexer.ch)
+		if lexer.peekChar() == '=' {
+			tok = lexer.readTwoCharToken(token.ASTERISK_ASSIGN)
+		} else {
+			tok = newToken(token.ASTERISK, lexer.ch)
+		}
+	case '%':
+		tok = newToken(token.PERCENT, lexer.ch)
 	case '<':
-		tok = newToken(token.LT, lexer.ch)
+		if lexer.peekChar() == '=' {
+			tok = lexer.readTwoCharToken(token.LT_EQ)
+		} else {
+			tok = newToken(token.LT, lexer.ch)
+		}
 	case '>':
-		tok = newToken(token.GT, lexer.ch)
+		if lexer.peekChar() == '=' {
+			tok = lexer.readTwoCharToken(token.GT_EQ)
+		} else {
+			tok = newToken(token.GT, lexer.ch)
+		}
+	case '&':
+		if lexer.peekChar() == '&' {
+			tok = lexer.readTwoCharToken(token.AND)
+		} else {
+			lexer.addError(lexer.currentPosition(), "unexpected character %q", lexer.ch)
+			tok = newToken(token.ILLEGAL, lexer.ch)
+		}
+	case '|':
+		if lexer.peekChar() == '|' {
+			tok = lexer.readTwoCharToken(token.OR)
+		} else if lexer.peekChar() == '>' {
+			tok = lexer.readTwoCharToken(token.PIPELINE)
+		} else {
+			tok = newToken(token.PIPE, lexer.ch)
+		}
 	case ';':
 		tok = newToken(token.SEMICOLON, lexer.ch)
+	case '.':
+		if lexer.peekCharAt(0) == '.' && lexer.peekCharAt(1) == '.' {
+			lexer.readChar()
+			lexer.readChar()
+			tok = token.Token{Type: token.ELLIPSIS, Literal: "..."}
+		} else {
+			tok = newToken(token.DOT, lexer.ch)
+		}
 	case ',':
 		tok = newToken(token.COMMA, lexer.ch)
 	case '(':
@@ -71,19 +292,39 @@ func (lexer *Lexer) NextToken() token.Token {
 	case ')':
 		tok = newToken(token.RPAREN, lexer.ch)
 	case '{':
+		if n := len(lexer.interpolations); n > 0 {
+			lexer.interpolations[n-1].braces++
+		}
 		tok = newToken(token.LBRACE, lexer.ch)
 	case '}':
-		tok = newToken(token.RBRACE, lexer.ch)
+		n := len(lexer.interpolations)
+		if n > 0 && lexer.interpolations[n-1].braces == 0 {
+			// 보간식이 끝났으므로 이어지는 문자열을 계속 읽음
+			top := lexer.interpolations[n-1]
+			lexer.interpolations = lexer.interpolations[:n-1]
+			tok = lexer.readStringPart(top.start, token.INTERP_MID, token.INTERP_TAIL)
+		} else {
+			if n > 0 {
+				lexer.interpolations[n-1].braces--
+			}
+			tok = newToken(token.RBRACE, lexer.ch)
+		}
 	case '[':
 		tok = newToken(token.LBRACKET, lexer.ch)
 	case ']':
 		tok = newToken(token.RBRACKET, lexer.ch)
 	case '"':
-		tok.Type = token.STRING
-		tok.Literal = lexer.readString()
+		tok = lexer.readStringPart(lexer.currentPosition(), token.INTERP_HEAD, token.STRING)
+	case '`':
+		tok.Type = token.RAW_STRING
+		tok.Literal = lexer.readRawString()
 	case ':':
 		tok = newToken(token.COLON, lexer.ch)
 	case 0:
+		if n := len(lexer.interpolations); n > 0 {
+			lexer.addError(lexer.interpolations[n-1].start, "unterminated string interpolation")
+			lexer.interpolations = nil
+		}
 		tok.Type = token.EOF
 		tok.Literal = ""
 	default:
@@ -92,10 +333,10 @@ func (lexer *Lexer) NextToken() token.Token {
 			tok.Type = token.LookupIdent(tok.Literal)
 			return tok
 		} else if isDigit(lexer.ch) {
-			tok.Type = token.INT
-			tok.Literal = lexer.readNumber()
+			tok.Type, tok.Literal = lexer.readNumber()
 			return tok
 		}
+		lexer.addError(lexer.currentPosition(), "unexpected character %q", lexer.ch)
 		tok = newToken(token.ILLEGAL, lexer.ch)
 	}
 
@@ -103,21 +344,29 @@ func (lexer *Lexer) NextToken() token.Token {
 	return tok
 }
 
-func newToken(tokenType token.TokenType, ch byte) token.Token {
+func newToken(tokenType token.TokenType, ch rune) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch)}
 }
 
+// 현재 문자와 다음 문자를 합쳐서 두 글자 연산자 토큰 생성 ex) ==, <=, &&
+func (lexer *Lexer) readTwoCharToken(tokenType token.TokenType) token.Token {
+	ch := lexer.ch
+	lexer.readChar()
+	return token.Token{Type: tokenType, Literal: string(ch) + string(lexer.ch)}
+}
+
 // 변수명, 예약어 파싱
 func (lexer *Lexer) readIdentifier() string {
-	position := lexer.position
+	mark := lexer.textMark()
 	for isLetter(lexer.ch) {
 		lexer.readChar()
 	}
-	return lexer.input[position:lexer.position]
+	return lexer.textFrom(mark)
 }
 
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+// 한글 등 유니코드 문자도 식별자로 사용 가능
+func isLetter(ch rune) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || ch >= utf8.RuneSelf && unicode.IsLetter(ch)
 }
 
 // 공백 및 줄바꿈 스킵
@@ -127,39 +376,299 @@ func (lexer *Lexer) skipWhiteSpace() {
 	}
 }
 
-// 정수 파싱 (실수는 미지원)
-func (lexer *Lexer) readNumber() string {
-	position := lexer.position
-	for isDigit(lexer.ch) {
+func (lexer *Lexer) isCommentStart() bool {
+	return lexer.ch == '/' && (lexer.peekChar() == '/' || lexer.peekChar() == '*')
+}
+
+// 주석 파싱 (// 주석은 줄바꿈 전까지, /* */ 주석은 닫는 기호까지)
+func (lexer *Lexer) readComment() string {
+	start := lexer.currentPosition()
+	mark := lexer.textMark()
+
+	if lexer.peekChar() == '/' {
+		for lexer.ch != '\n' && lexer.ch != 0 {
+			lexer.readChar()
+		}
+		return lexer.textFrom(mark)
+	}
+
+	// '/*' 스킵
+	lexer.readChar()
+	lexer.readChar()
+
+	for {
+		if lexer.ch == 0 {
+			lexer.addError(start, "unterminated block comment")
+			return lexer.textFrom(mark)
+		}
+		if lexer.ch == '*' && lexer.peekChar() == '/' {
+			lexer.readChar()
+			lexer.readChar()
+			return lexer.textFrom(mark)
+		}
+		lexer.readChar()
+	}
+}
+
+// 진법 접두사별 이름과 허용되는 숫자
+var numberBases = map[rune]struct {
+	name    string
+	isDigit func(rune) bool
+}{
+	'x': {"hexadecimal", isHexDigit},
+	'o': {"octal", isOctalDigit},
+	'b': {"binary", isBinaryDigit},
+}
+
+// 숫자 파싱 (소수점이나 지수가 있으면 실수, 0x/0o/0b 접두사가 있으면 해당 진법의 정수)
+func (lexer *Lexer) readNumber() (token.TokenType, string) {
+	start := lexer.currentPosition()
+	mark := lexer.textMark()
+
+	if lexer.ch == '0' {
+		if _, ok := numberBases[unicode.ToLower(lexer.peekChar())]; ok {
+			return lexer.readPrefixedInteger()
+		}
+	}
+
+	tokenType := token.TokenType(token.INT)
+
+	lexer.readDigits()
+
+	// 소수점 뒤에 숫자가 있어야 실수 ex) 3.14
+	if lexer.ch == '.' && isDigit(lexer.peekChar()) {
+		tokenType = token.FLOAT
+		lexer.readChar()
+		lexer.readDigits()
+	}
+
+	// 지수 표기 ex) 1.5e-3, 2E10
+	if (lexer.ch == 'e' || lexer.ch == 'E') && lexer.isExponentStart() {
+		tokenType = token.FLOAT
+		lexer.readChar()
+		if lexer.ch == '+' || lexer.ch == '-' {
+			lexer.readChar()
+		}
+		lexer.readDigits()
+	}
+
+	// 숫자 바로 뒤에 문자가 붙어 있으면 잘못된 숫자 ex) 123abc, 1.5e, 2.0.1
+	if isLetter(lexer.ch) || lexer.ch == '.' && isDigit(lexer.peekChar()) {
+		for isLetter(lexer.ch) || isDigit(lexer.ch) || lexer.ch == '.' {
+			lexer.readChar()
+		}
+		literal := lexer.textFrom(mark)
+		lexer.addError(start, "malformed number %q", literal)
+		return token.ILLEGAL, literal
+	}
+
+	literal := lexer.textFrom(mark)
+	if !validSeparators(literal, 0, isDigit) {
+		lexer.addError(start, "'_' must separate successive digits in %q", literal)
+		return token.ILLEGAL, literal
+	}
+
+	// 0 으로 시작하는 정수는 8진수로 오해하기 쉬우므로 허용하지 않음 ex) 0755, 08
+	if tokenType == token.INT && len(literal) > 1 && literal[0] == '0' {
+		lexer.addError(start, "invalid integer literal %q: use 0o prefix for octal literals", literal)
+		return token.ILLEGAL, literal
+	}
+
+	return tokenType, literal
+}
+
+// 0x, 0o, 0b 접두사가 붙은 정수 파싱 ex) 0xFF, 0o755, 0b1010_0101
+func (lexer *Lexer) readPrefixedInteger() (token.TokenType, string) {
+	start := lexer.currentPosition()
+	mark := lexer.textMark()
+
+	lexer.readChar() // '0' 스킵
+	base := numberBases[unicode.ToLower(lexer.ch)]
+	lexer.readChar() // 접두사 스킵
+
+	// 잘못된 숫자까지 한 번에 에러로 보여주기 위해 붙어있는 문자는 모두 읽음
+	for isLetter(lexer.ch) || isDigit(lexer.ch) {
+		lexer.readChar()
+	}
+	literal := lexer.textFrom(mark)
+
+	digits := strings.ReplaceAll(literal[2:], "_", "")
+	if digits == "" {
+		lexer.addError(start, "%s literal %q has no digits", base.name, literal)
+		return token.ILLEGAL, literal
+	}
+	for _, ch := range digits {
+		if !base.isDigit(ch) {
+			lexer.addError(start, "invalid digit %q in %s literal %q", ch, base.name, literal)
+			return token.ILLEGAL, literal
+		}
+	}
+	if !validSeparators(literal, 2, base.isDigit) {
+		lexer.addError(start, "'_' must separate successive digits in %q", literal)
+		return token.ILLEGAL, literal
+	}
+
+	return token.INT, literal
+}
+
+// 숫자와 '_' 를 읽음
+func (lexer *Lexer) readDigits() {
+	for isDigit(lexer.ch) || lexer.ch == '_' {
 		lexer.readChar()
 	}
-	return lexer.input[position:lexer.position]
 }
 
-func isDigit(ch byte) bool {
+// '_' 는 숫자 사이에만 올 수 있음 (0x_FF 처럼 접두사 바로 뒤는 허용)
+func validSeparators(literal string, prefixLen int, isBaseDigit func(rune) bool) bool {
+	for i, ch := range literal {
+		if ch != '_' {
+			continue
+		}
+		if i+1 >= len(literal) || !isBaseDigit(rune(literal[i+1])) {
+			return false
+		}
+		if i != prefixLen && !isBaseDigit(rune(literal[i-1])) {
+			return false
+		}
+	}
+	return true
+}
+
+// 'e' 뒤에 (부호와) 숫자가 오는지 확인
+func (lexer *Lexer) isExponentStart() bool {
+	next := lexer.peekChar()
+	if next == '+' || next == '-' {
+		next = lexer.peekCharAt(1)
+	}
+	return isDigit(next)
+}
+
+func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9'
 }
 
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isOctalDigit(ch rune) bool {
+	return '0' <= ch && ch <= '7'
+}
+
+func isBinaryDigit(ch rune) bool {
+	return ch == '0' || ch == '1'
+}
+
 // 다음에 나올 입력을 살펴봄(=peek)
-func (lexer *Lexer) peekChar() byte {
-	if lexer.readPosition >= len(lexer.input) {
-		return 0
-	}
+func (lexer *Lexer) peekChar() rune {
+	return lexer.peekCharAt(0)
+}
 
-	return lexer.input[lexer.readPosition]
+// 문자열 조각 하나를 토큰으로 만듦
+// ${ 를 만나면 interpType 토큰을 만들고 보간 상태를 쌓음, 닫는 따옴표를 만나면 endType 토큰
+func (lexer *Lexer) readStringPart(start token.Position, interpType, endType token.TokenType) token.Token {
+	literal, interpolated := lexer.readString(start)
+	if interpolated {
+		lexer.interpolations = append(lexer.interpolations, interpolation{start: start})
+		return token.Token{Type: interpType, Literal: literal}
+	}
+	return token.Token{Type: endType, Literal: literal}
 }
 
-func (lexer *Lexer) readString() string {
-	// 따옴표 다음 문자
-	start := lexer.readPosition
+// 문자열 파싱 (이스케이프 시퀀스는 실제 문자로 변환)
+// 여는 따옴표(혹은 보간식을 닫는 중괄호) 위치에서 호출되며, ${ 에서 멈추면 true 리턴
+func (lexer *Lexer) readString(start token.Position) (string, bool) {
+	var out strings.Builder
+
+	lexer.readChar() // 여는 따옴표 스킵
 
 	// 큰따옴표 혹은 입력의 끝까지 문자열을 계속 읽음
-	for {
+	for lexer.ch != '"' && lexer.ch != 0 {
+		if lexer.ch == '$' && lexer.peekChar() == '{' {
+			lexer.readChar() // '{' 는 readToken 에서 스킵
+			return out.String(), true
+		}
+		if lexer.ch == '\\' {
+			lexer.readEscape(&out)
+			continue
+		}
+		out.WriteRune(lexer.ch)
 		lexer.readChar()
-		if lexer.ch == '"' || lexer.ch == 0 {
-			break
+	}
+
+	if lexer.ch == 0 {
+		lexer.addError(start, "unterminated string literal")
+	}
+
+	return out.String(), false
+}
+
+// 백틱 문자열 파싱 (줄바꿈, 역슬래시 등을 변환하지 않고 그대로 읽음)
+func (lexer *Lexer) readRawString() string {
+	start := lexer.currentPosition()
+
+	lexer.readChar() // 여는 백틱 스킵
+	mark := lexer.textMark()
+
+	for lexer.ch != '`' && lexer.ch != 0 {
+		lexer.readChar()
+	}
+
+	if lexer.ch == 0 {
+		lexer.addError(start, "unterminated raw string literal")
+	}
+
+	return lexer.textFrom(mark)
+}
+
+var escapes = map[rune]rune{
+	'n':  '\n',
+	't':  '\t',
+	'r':  '\r',
+	'0':  0,
+	'\\': '\\',
+	'"':  '"',
+	'$':  '$',
+}
+
+// 역슬래시부터 이스케이프 시퀀스 끝까지 읽음 ex) \n, \", \u{AC00}
+func (lexer *Lexer) readEscape(out *strings.Builder) {
+	start := lexer.currentPosition()
+	lexer.readChar() // 역슬래시 스킵
+
+	if ch, ok := escapes[lexer.ch]; ok {
+		out.WriteRune(ch)
+		lexer.readChar()
+		return
+	}
+
+	if lexer.ch == 'u' && lexer.peekChar() == '{' {
+		lexer.readChar() // 'u' 스킵
+		lexer.readChar() // '{' 스킵
+
+		mark := lexer.textMark()
+		for lexer.ch != '}' && lexer.ch != '"' && lexer.ch != 0 {
+			lexer.readChar()
+		}
+		hex := lexer.textFrom(mark)
+		if lexer.ch != '}' {
+			lexer.addError(start, "unterminated unicode escape \\u{%s", hex)
+			return
+		}
+		lexer.readChar() // '}' 스킵
+
+		code, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) > 6 || !utf8.ValidRune(rune(code)) {
+			lexer.addError(start, "invalid unicode escape \\u{%s}", hex)
+			return
 		}
+		out.WriteRune(rune(code))
+		return
 	}
 
-	return lexer.input[start:lexer.position]
+	if lexer.ch == 0 {
+		return
+	}
+	lexer.addError(start, "invalid escape sequence \\%c", lexer.ch)
+	lexer.readChar()
 }
@@ -2,7 +2,9 @@ package lexer
 
 import (
 	"interpreter-go/token"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func TestNextToken(t *testing.T) {
@@ -16,7 +18,7 @@ let add = fn(x, y) {
 };
 
 let result = add(five, ten);
-!-/*5;
+!-/ *5;
 5 < 10 > 5;
 
 if (5 < 10) {
@@ -35,7 +37,10 @@ if (5 < 10) {
 `
 
 	// 렉서로 파싱하였을 때 예상되는 토큰 리스트
-	expectedTokens := []token.Token{
+	expectedTokens := []struct {
+		Type    token.TokenType
+		Literal string
+	}{
 		{token.LET, "let"},
 		{token.IDENT, "five"},
 		{token.ASSIGN, "="},
@@ -137,3 +142,538 @@ if (5 < 10) {
 		}
 	}
 }
+
+func TestTokenPositions(t *testing.T) {
+	input := "let x = 5;\n  x + 10"
+
+	expectedPositions := []struct {
+		literal string
+		pos     string
+		offset  int
+		end     string
+	}{
+		{"let", "test.mk:1:1", 0, "test.mk:1:4"},
+		{"x", "test.mk:1:5", 4, "test.mk:1:6"},
+		{"=", "test.mk:1:7", 6, "test.mk:1:8"},
+		{"5", "test.mk:1:9", 8, "test.mk:1:10"},
+		{";", "test.mk:1:10", 9, "test.mk:1:11"},
+		{"x", "test.mk:2:3", 13, "test.mk:2:4"},
+		{"+", "test.mk:2:5", 15, "test.mk:2:6"},
+		{"10", "test.mk:2:7", 17, "test.mk:2:9"},
+		{"", "test.mk:2:9", 19, "test.mk:2:9"},
+		{"", "test.mk:2:9", 19, "test.mk:2:9"},
+	}
+
+	lexer := NewFile("test.mk", input)
+
+	for i, expected := range expectedPositions {
+		tok := lexer.NextToken()
+		if tok.Literal != expected.literal {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, expected.literal, tok.Literal)
+		}
+		if tok.Pos.String() != expected.pos {
+			t.Errorf("tests[%d] - pos wrong. expected=%q, got=%q", i, expected.pos, tok.Pos.String())
+		}
+		if tok.Pos.Offset != expected.offset {
+			t.Errorf("tests[%d] - offset wrong. expected=%d, got=%d", i, expected.offset, tok.Pos.Offset)
+		}
+		if tok.End.String() != expected.end {
+			t.Errorf("tests[%d] - end wrong. expected=%q, got=%q", i, expected.end, tok.End.String())
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `// 한 줄 주석
+let x = 5; // 뒤에 붙은 주석
+/* 여러
+   줄 주석 */ x / 2;
+`
+
+	tests := []struct {
+		mode           Mode
+		expectedTokens []token.TokenType
+	}{
+		{0, []token.TokenType{
+			token.LET, token.IDENT, token.ASSIGN, token.INT, token.SEMICOLON,
+			token.IDENT, token.SLASH, token.INT, token.SEMICOLON, token.EOF,
+		}},
+		{ScanComments, []token.TokenType{
+			token.COMMENT,
+			token.LET, token.IDENT, token.ASSIGN, token.INT, token.SEMICOLON,
+			token.COMMENT, token.COMMENT,
+			token.IDENT, token.SLASH, token.INT, token.SEMICOLON, token.EOF,
+		}},
+	}
+
+	for _, tt := range tests {
+		lexer := New(input)
+		lexer.SetMode(tt.mode)
+
+		for i, expected := range tt.expectedTokens {
+			tok := lexer.NextToken()
+			if tok.Type != expected {
+				t.Fatalf("mode %d tokens[%d] - tokentype wrong. expected=%q, got=%q(%q)", tt.mode, i, expected, tok.Type, tok.Literal)
+			}
+		}
+	}
+
+	lexer := New(input)
+	lexer.SetMode(ScanComments)
+	if tok := lexer.NextToken(); tok.Literal != "// 한 줄 주석" {
+		t.Errorf("line comment literal wrong. got=%q", tok.Literal)
+	}
+	if len(lexer.Errors()) != 0 {
+		t.Errorf("lexer has unexpected errors: %v", lexer.Errors())
+	}
+}
+
+func TestUnterminatedBlockComment(t *testing.T) {
+	lexer := New("let x = 1;\n/* 닫히지 않은 주석")
+
+	for tok := lexer.NextToken(); tok.Type != token.EOF; tok = lexer.NextToken() {
+	}
+
+	errors := lexer.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("lexer has wrong number of errors. got=%d", len(errors))
+	}
+	if errors[0] != "2:1: unterminated block comment" {
+		t.Errorf("wrong error. got=%q", errors[0])
+	}
+}
+
+func TestNumberLiterals(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{"42", token.INT, "42"},
+		{"3.14", token.FLOAT, "3.14"},
+		{"0.5", token.FLOAT, "0.5"},
+		{"1.5e-3", token.FLOAT, "1.5e-3"},
+		{"2E10", token.FLOAT, "2E10"},
+		{"6e+2", token.FLOAT, "6e+2"},
+		{"0xFF", token.INT, "0xFF"},
+		{"0Xab_CD", token.INT, "0Xab_CD"},
+		{"0o755", token.INT, "0o755"},
+		{"0b1010_0101", token.INT, "0b1010_0101"},
+		{"0x_FF", token.INT, "0x_FF"},
+		{"1_000_000", token.INT, "1_000_000"},
+		{"1_000.000_1", token.FLOAT, "1_000.000_1"},
+		{"0", token.INT, "0"},
+		{"0e3", token.FLOAT, "0e3"},
+		// 0 으로 시작하는 10진 정수는 에러
+		{"0755", token.ILLEGAL, "0755"},
+		{"08", token.ILLEGAL, "08"},
+	}
+
+	for _, tt := range tests {
+		tok := New(tt.input).NextToken()
+		if tok.Type != tt.expectedType {
+			t.Errorf("%q - tokentype wrong. expected=%q, got=%q", tt.input, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Errorf("%q - literal wrong. expected=%q, got=%q", tt.input, tt.expectedLiteral, tok.Literal)
+		}
+	}
+
+	// 소수점 뒤에 숫자가 없으면 정수에서 끝남
+	lexer := New("1.x")
+	expectedTokens := []token.TokenType{token.INT, token.DOT, token.IDENT, token.EOF}
+	for i, expected := range expectedTokens {
+		tok := lexer.NextToken()
+		if tok.Type != expected {
+			t.Fatalf("tokens[%d] - tokentype wrong. expected=%q, got=%q(%q)", i, expected, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestLexerErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedLiteral string
+		expectedError   string
+	}{
+		{"let x = @;", "@", "1:9: unexpected character '@'"},
+		{"\n  #", "#", "2:3: unexpected character '#'"},
+		{"123abc", "123abc", `1:1: malformed number "123abc"`},
+		{"x = 2e;", "2e", `1:5: malformed number "2e"`},
+		{"1.5e-", "1.5e", `1:1: malformed number "1.5e"`},
+		{"1.2.3", "1.2.3", `1:1: malformed number "1.2.3"`},
+		{"0x", "0x", `1:1: hexadecimal literal "0x" has no digits`},
+		{"0b102", "0b102", `1:1: invalid digit '2' in binary literal "0b102"`},
+		{"0o78", "0o78", `1:1: invalid digit '8' in octal literal "0o78"`},
+		{"0xFG", "0xFG", `1:1: invalid digit 'G' in hexadecimal literal "0xFG"`},
+		{"1__000", "1__000", `1:1: '_' must separate successive digits in "1__000"`},
+		{"100_", "100_", `1:1: '_' must separate successive digits in "100_"`},
+		{"1_.5", "1_.5", `1:1: '_' must separate successive digits in "1_.5"`},
+		{"0b_", "0b_", `1:1: binary literal "0b_" has no digits`},
+		{"0x1__F", "0x1__F", `1:1: '_' must separate successive digits in "0x1__F"`},
+		{"0755", "0755", `1:1: invalid integer literal "0755": use 0o prefix for octal literals`},
+		{"x = 08;", "08", `1:5: invalid integer literal "08": use 0o prefix for octal literals`},
+		{`"hello`, "hello", "1:1: unterminated string literal"},
+		{"let s = \"a\\", "a", "1:9: unterminated string literal"},
+	}
+
+	for _, tt := range tests {
+		lexer := New(tt.input)
+
+		found := false
+		for tok := lexer.NextToken(); tok.Type != token.EOF; tok = lexer.NextToken() {
+			if tok.Literal == tt.expectedLiteral {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%q - no token with literal %q", tt.input, tt.expectedLiteral)
+		}
+
+		errors := lexer.Errors()
+		if len(errors) != 1 {
+			t.Errorf("%q - lexer has wrong number of errors. got=%v", tt.input, errors)
+			continue
+		}
+		if errors[0] != tt.expectedError {
+			t.Errorf("%q - wrong error. expected=%q, got=%q", tt.input, tt.expectedError, errors[0])
+		}
+	}
+}
+
+func TestStringEscapes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"a\nb"`, "a\nb"},
+		{`"tab\there"`, "tab\there"},
+		{`"back\\slash"`, `back\slash`},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"\u{AC00}\u{41}"`, "가A"},
+		{`"안녕하세요"`, "안녕하세요"},
+	}
+
+	for _, tt := range tests {
+		lexer := New(tt.input)
+		tok := lexer.NextToken()
+		if tok.Type != token.STRING {
+			t.Fatalf("%s - tokentype wrong. got=%q", tt.input, tok.Type)
+		}
+		if tok.Literal != tt.expected {
+			t.Errorf("%s - literal wrong. expected=%q, got=%q", tt.input, tt.expected, tok.Literal)
+		}
+		if len(lexer.Errors()) != 0 {
+			t.Errorf("%s - lexer has unexpected errors: %v", tt.input, lexer.Errors())
+		}
+		if next := lexer.NextToken(); next.Type != token.EOF {
+			t.Errorf("%s - expected EOF after string. got=%q", tt.input, next.Type)
+		}
+	}
+}
+
+func TestInvalidStringEscapes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"a\qb"`, `1:3: invalid escape sequence \q`},
+		{`"\u{110000}"`, `1:2: invalid unicode escape \u{110000}`},
+		{`"\u{zz}"`, `1:2: invalid unicode escape \u{zz}`},
+		{`"\u{41"`, `1:2: unterminated unicode escape \u{41`},
+	}
+
+	for _, tt := range tests {
+		lexer := New(tt.input)
+		lexer.NextToken()
+
+		errors := lexer.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("%s - lexer has wrong number of errors. got=%v", tt.input, errors)
+		}
+		if errors[0] != tt.expected {
+			t.Errorf("%s - wrong error. expected=%q, got=%q", tt.input, tt.expected, errors[0])
+		}
+		if next := lexer.NextToken(); next.Type != token.EOF {
+			t.Errorf("%s - expected EOF after string. got=%q", tt.input, next.Type)
+		}
+	}
+}
+
+func TestUnicodeIdentifiers(t *testing.T) {
+	lexer := New(`let 이름 = "값"; 이름`)
+
+	expectedTokens := []struct {
+		Type    token.TokenType
+		Literal string
+		Column  int
+	}{
+		{token.LET, "let", 1},
+		{token.IDENT, "이름", 5},
+		{token.ASSIGN, "=", 8},
+		{token.STRING, "값", 10},
+		{token.SEMICOLON, ";", 13},
+		{token.IDENT, "이름", 15},
+		{token.EOF, "", 17},
+	}
+
+	for i, expected := range expectedTokens {
+		tok := lexer.NextToken()
+		if tok.Type != expected.Type || tok.Literal != expected.Literal {
+			t.Fatalf("tokens[%d] - wrong token. expected=%q(%q), got=%q(%q)", i, expected.Type, expected.Literal, tok.Type, tok.Literal)
+		}
+		if tok.Pos.Column != expected.Column {
+			t.Errorf("tokens[%d] - column wrong. expected=%d, got=%d", i, expected.Column, tok.Pos.Column)
+		}
+	}
+}
+
+func TestLoopKeywords(t *testing.T) {
+	lexer := New(`while (true) { break; continue; } whiles for (x in xs)`)
+
+	expectedTokens := []struct {
+		Type    token.TokenType
+		Literal string
+	}{
+		{token.WHILE, "while"},
+		{token.LPAREN, "("},
+		{token.TRUE, "true"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.BREAK, "break"},
+		{token.SEMICOLON, ";"},
+		{token.CONTINUE, "continue"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.IDENT, "whiles"},
+		{token.FOR, "for"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.IN, "in"},
+		{token.IDENT, "xs"},
+		{token.RPAREN, ")"},
+		{token.EOF, ""},
+	}
+
+	for i, expected := range expectedTokens {
+		tok := lexer.NextToken()
+		if tok.Type != expected.Type || tok.Literal != expected.Literal {
+			t.Fatalf("tokens[%d] - wrong token. expected=%q(%q), got=%q(%q)", i, expected.Type, expected.Literal, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestOperators(t *testing.T) {
+	input := `a <= b >= c % d && e || f < g
+x = 1 += 2 -= 3 *= 4 /= 5 ...rest |y| |> match _ => z`
+
+	expectedTokens := []struct {
+		Type    token.TokenType
+		Literal string
+	}{
+		{token.IDENT, "a"},
+		{token.LT_EQ, "<="},
+		{token.IDENT, "b"},
+		{token.GT_EQ, ">="},
+		{token.IDENT, "c"},
+		{token.PERCENT, "%"},
+		{token.IDENT, "d"},
+		{token.AND, "&&"},
+		{token.IDENT, "e"},
+		{token.OR, "||"},
+		{token.IDENT, "f"},
+		{token.LT, "<"},
+		{token.IDENT, "g"},
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "1"},
+		{token.PLUS_ASSIGN, "+="},
+		{token.INT, "2"},
+		{token.MINUS_ASSIGN, "-="},
+		{token.INT, "3"},
+		{token.ASTERISK_ASSIGN, "*="},
+		{token.INT, "4"},
+		{token.SLASH_ASSIGN, "/="},
+		{token.INT, "5"},
+		{token.ELLIPSIS, "..."},
+		{token.IDENT, "rest"},
+		{token.PIPE, "|"},
+		{token.IDENT, "y"},
+		{token.PIPE, "|"},
+		{token.PIPELINE, "|>"},
+		{token.MATCH, "match"},
+		{token.IDENT, "_"},
+		{token.ARROW, "=>"},
+		{token.IDENT, "z"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, expectedToken := range expectedTokens {
+		lexerToken := lexer.NextToken()
+		if lexerToken.Type != expectedToken.Type || lexerToken.Literal != expectedToken.Literal {
+			t.Fatalf("expectedTokens[%d] - wrong token. expected=%q(%q), got=%q(%q)", i, expectedToken.Type, expectedToken.Literal, lexerToken.Type, lexerToken.Literal)
+		}
+	}
+}
+
+func TestModuleTokens(t *testing.T) {
+	lexer := New(`import "lib/strings.mk" as s; export fn f() { s.upper(x) }`)
+
+	expectedTokens := []struct {
+		Type    token.TokenType
+		Literal string
+	}{
+		{token.IMPORT, "import"},
+		{token.STRING, "lib/strings.mk"},
+		{token.IDENT, "as"},
+		{token.IDENT, "s"},
+		{token.SEMICOLON, ";"},
+		{token.EXPORT, "export"},
+		{token.FUNCTION, "fn"},
+		{token.IDENT, "f"},
+		{token.LPAREN, "("},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.IDENT, "s"},
+		{token.DOT, "."},
+		{token.IDENT, "upper"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.RPAREN, ")"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	for i, expected := range expectedTokens {
+		tok := lexer.NextToken()
+		if tok.Type != expected.Type || tok.Literal != expected.Literal {
+			t.Fatalf("tokens[%d] - wrong token. expected=%q(%q), got=%q(%q)", i, expected.Type, expected.Literal, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestStringInterpolation(t *testing.T) {
+	input := `"Hello ${user["name"]}, you have ${count + 1} items" "${ {"a": "${x}"} }" "\${raw}"`
+
+	expectedTokens := []struct {
+		Type    token.TokenType
+		Literal string
+	}{
+		{token.INTERP_HEAD, "Hello "},
+		{token.IDENT, "user"},
+		{token.LBRACKET, "["},
+		{token.STRING, "name"},
+		{token.RBRACKET, "]"},
+		{token.INTERP_MID, ", you have "},
+		{token.IDENT, "count"},
+		{token.PLUS, "+"},
+		{token.INT, "1"},
+		{token.INTERP_TAIL, " items"},
+		{token.INTERP_HEAD, ""},
+		{token.LBRACE, "{"},
+		{token.STRING, "a"},
+		{token.COLON, ":"},
+		{token.INTERP_HEAD, ""},
+		{token.IDENT, "x"},
+		{token.INTERP_TAIL, ""},
+		{token.RBRACE, "}"},
+		{token.INTERP_TAIL, ""},
+		{token.STRING, "${raw}"},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, expectedToken := range expectedTokens {
+		lexerToken := lexer.NextToken()
+		if lexerToken.Type != expectedToken.Type || lexerToken.Literal != expectedToken.Literal {
+			t.Fatalf("expectedTokens[%d] - wrong token. expected=%q(%q), got=%q(%q)", i, expectedToken.Type, expectedToken.Literal, lexerToken.Type, lexerToken.Literal)
+		}
+	}
+	if len(lexer.Errors()) != 0 {
+		t.Errorf("lexer has unexpected errors: %v", lexer.Errors())
+	}
+
+	lexer = New(`let s = "a ${x`)
+	for tok := lexer.NextToken(); tok.Type != token.EOF; tok = lexer.NextToken() {
+	}
+	if errors := lexer.Errors(); len(errors) != 1 || errors[0] != "1:9: unterminated string interpolation" {
+		t.Errorf("wrong errors. got=%q", errors)
+	}
+}
+
+func TestRawStrings(t *testing.T) {
+	input := "`SELECT *\n  FROM t\n WHERE a = \"x\"` `\\d+\\.${n}`"
+
+	expectedTokens := []struct {
+		Type    token.TokenType
+		Literal string
+	}{
+		{token.RAW_STRING, "SELECT *\n  FROM t\n WHERE a = \"x\""},
+		{token.RAW_STRING, `\d+\.${n}`},
+		{token.EOF, ""},
+	}
+
+	lexer := New(input)
+
+	for i, expectedToken := range expectedTokens {
+		lexerToken := lexer.NextToken()
+		if lexerToken.Type != expectedToken.Type || lexerToken.Literal != expectedToken.Literal {
+			t.Fatalf("expectedTokens[%d] - wrong token. expected=%q(%q), got=%q(%q)", i, expectedToken.Type, expectedToken.Literal, lexerToken.Type, lexerToken.Literal)
+		}
+	}
+
+	lexer = New("let s = `abc")
+	for tok := lexer.NextToken(); tok.Type != token.EOF; tok = lexer.NextToken() {
+	}
+	if errors := lexer.Errors(); len(errors) != 1 || errors[0] != "1:9: unterminated raw string literal" {
+		t.Errorf("wrong errors. got=%q", errors)
+	}
+}
+
+func TestNewReaderMatchesNew(t *testing.T) {
+	input := `// 주석
+let 이름 = "안녕 ${x + 1}\n";
+let f = fn(a, b) { a <= b && b % 2 == 0 };
+/* 블록 주석 */ [0xFF, 1_000, 1.5e-3, ` + "`raw\\`" + `];
+{"key": 'bad'}
+`
+
+	for _, mode := range []Mode{0, ScanComments} {
+		expected := New(input)
+		expected.SetMode(mode)
+
+		// 한 바이트씩만 읽히는 reader로 UTF-8 문자가 잘려서 들어오는 경우까지 확인
+		actual := NewReader(iotest.OneByteReader(strings.NewReader(input)))
+		actual.SetMode(mode)
+
+		for i := 0; ; i++ {
+			expectedToken := expected.NextToken()
+			actualToken := actual.NextToken()
+			if actualToken != expectedToken {
+				t.Fatalf("mode %d tokens[%d] - wrong token. expected=%+v, got=%+v", mode, i, expectedToken, actualToken)
+			}
+			if expectedToken.Type == token.EOF {
+				break
+			}
+		}
+
+		if strings.Join(actual.Errors(), "\n") != strings.Join(expected.Errors(), "\n") {
+			t.Errorf("mode %d - errors differ. expected=%q, got=%q", mode, expected.Errors(), actual.Errors())
+		}
+	}
+}
+
+func TestNewReaderError(t *testing.T) {
+	lexer := NewFileReader("pipe", iotest.DataErrReader(iotest.TimeoutReader(strings.NewReader("let x = 1;"))))
+
+	for tok := lexer.NextToken(); tok.Type != token.EOF; tok = lexer.NextToken() {
+	}
+
+	errs := lexer.Errors()
+	if len(errs) != 1 || !strings.Contains(errs[0], iotest.ErrTimeout.Error()) {
+		t.Errorf("wrong errors. got=%q", errs)
+	}
+}
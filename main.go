@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"interpreter-go/evaluator"
 	"interpreter-go/repl"
 	"os"
 	"os/user"
+	"path/filepath"
 )
 
 func main() {
@@ -12,6 +14,12 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+
+	// import 할 모듈을 찾을 경로 (MONKEY_PATH 에 경로 구분자로 나열, 없으면 현재 디렉터리)
+	if path := os.Getenv("MONKEY_PATH"); path != "" {
+		evaluator.Modules.SearchPaths = filepath.SplitList(path)
+	}
+
 	fmt.Printf("Hello %s! This is the Monkey programming language!\n", currentUser.Username)
 	fmt.Printf("Feel free to type in commands\n")
 	repl.Start(os.Stdin, os.Stdout)
@@ -10,12 +10,13 @@ func NewEnclosedEnvironment(outer *Environment) *Environment {
 
 func NewEnvironment() *Environment {
 	s := make(map[string]Object)
-	return &Environment{store: s, outer: nil}
+	return &Environment{store: s, constants: make(map[string]bool), outer: nil}
 }
 
 type Environment struct {
-	store map[string]Object
-	outer *Environment
+	store     map[string]Object
+	constants map[string]bool // const 로 선언된 이름 (읽기 전용)
+	outer     *Environment
 }
 
 func (e *Environment) Get(name string) (Object, bool) {
@@ -31,3 +32,43 @@ func (e *Environment) Set(name string, val Object) Object {
 	e.store[name] = val
 	return val
 }
+
+// SetConst : 다시 대입하거나 같은 환경에서 다시 선언할 수 없는 상수로 저장
+func (e *Environment) SetConst(name string, val Object) Object {
+	e.store[name] = val
+	e.constants[name] = true
+	return val
+}
+
+// Declared : 바깥 환경은 보지 않고 현재 환경에 선언된 이름인지 확인
+func (e *Environment) Declared(name string) bool {
+	_, ok := e.store[name]
+	return ok
+}
+
+// IsConst : 이름이 선언된 가장 가까운 환경에서 상수로 선언되었는지 확인
+func (e *Environment) IsConst(name string) bool {
+	if _, ok := e.store[name]; ok {
+		return e.constants[name]
+	}
+	if e.outer != nil {
+		return e.outer.IsConst(name)
+	}
+	return false
+}
+
+// Assign : 변수가 선언된 환경을 찾아 거슬러 올라가서 그 환경의 값을 변경
+// Set과 달리 새 변수를 만들지 않으므로 선언되지 않은 변수 혹은 상수면 false를 리턴
+func (e *Environment) Assign(name string, val Object) (Object, bool) {
+	if _, ok := e.store[name]; ok {
+		if e.constants[name] {
+			return nil, false
+		}
+		e.store[name] = val
+		return val, true
+	}
+	if e.outer != nil {
+		return e.outer.Assign(name, val)
+	}
+	return nil, false
+}
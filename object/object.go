@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"hash/fnv"
 	"interpreter-go/ast"
+	"interpreter-go/token"
+	"strconv"
 	"strings"
 )
 
@@ -12,15 +14,22 @@ type ObjectType string
 
 const (
 	INTEGER_OBJ      = "INTEGER"
+	FLOAT_OBJ        = "FLOAT"
 	BOOLEAN_OBJ      = "BOOLEAN"
 	NULL_OBJ         = "NULL"
 	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	BREAK_OBJ        = "BREAK"
+	CONTINUE_OBJ     = "CONTINUE"
 	ERROR_OBJ        = "ERROR"
+	ERROR_VALUE_OBJ  = "ERROR_VALUE"
 	FUNCTION_OBJ     = "FUNCTION"
 	STRING_OBJ       = "STRING"
 	BUILTIN_OBJ      = "BUILTIN"
 	ARRAY_OBJ        = "ARRAY"
 	HASH_OBJ         = "HASH"
+	QUOTE_OBJ        = "QUOTE"
+	MACRO_OBJ        = "MACRO"
+	MODULE_OBJ       = "MODULE"
 )
 
 type Object interface {
@@ -35,6 +44,21 @@ type Integer struct {
 func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
 func (i *Integer) Inspect() string  { return fmt.Sprintf("%d", i.Value) }
 
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Type() ObjectType { return FLOAT_OBJ }
+
+// 정수와 구분되도록 소수부가 없어도 .0 을 붙여서 출력 ex) 2.0
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eIN") {
+		s += ".0"
+	}
+	return s
+}
+
 type Boolean struct {
 	Value bool
 }
@@ -54,15 +78,65 @@ type ReturnValue struct {
 func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
 func (rv *ReturnValue) Inspect() string  { return rv.Value.Inspect() }
 
+// Break : break 구문을 만났다는 신호 (ReturnValue처럼 반복문까지 거슬러 올라가서 처리됨)
+type Break struct{}
+
+func (b *Break) Type() ObjectType { return BREAK_OBJ }
+func (b *Break) Inspect() string  { return "break" }
+
+// Continue : continue 구문을 만났다는 신호
+type Continue struct{}
+
+func (c *Continue) Type() ObjectType { return CONTINUE_OBJ }
+func (c *Continue) Inspect() string  { return "continue" }
+
+// Error : 던져진 에러, try 구문에서 잡히기 전까지 실행을 중단시키며 전파됨
 type Error struct {
 	Message string
+	Kind    string         // 에러 종류 ex) RuntimeError, Error
+	Data    Object         // error(msg, data) 로 붙인 값 (없으면 nil)
+	Pos     token.Position // 에러가 발생한 노드의 위치
+	Frames  []string       // 에러가 빠져나온 이름 있는 함수들 (안쪽 함수부터)
 }
 
 func (e *Error) Type() ObjectType { return ERROR_OBJ }
-func (e *Error) Inspect() string  { return "ERROR: " + e.Message }
+func (e *Error) Inspect() string {
+	var out bytes.Buffer
+
+	out.WriteString("ERROR: ")
+	if e.Pos.IsValid() {
+		out.WriteString(e.Pos.String() + ": ")
+	}
+	out.WriteString(e.Message)
+	if len(e.Frames) > 0 {
+		out.WriteString(" (in " + strings.Join(e.Frames, " <- ") + ")")
+	}
+
+	return out.String()
+}
+
+// ErrorValue : catch 로 잡힌 에러 혹은 error() 로 만든 에러 값
+// Error 와 달리 전파되지 않는 보통의 값이라서 변수에 담거나 인덱스로 조회할 수 있음
+type ErrorValue struct {
+	Message string
+	Kind    string
+	Data    Object
+	Pos     token.Position // 던져진 위치 (아직 던져지지 않았으면 유효하지 않음)
+}
+
+func (ev *ErrorValue) Type() ObjectType { return ERROR_VALUE_OBJ }
+func (ev *ErrorValue) Inspect() string {
+	if ev.Pos.IsValid() {
+		return ev.Kind + ": " + ev.Message + " (at " + ev.Pos.String() + ")"
+	}
+	return ev.Kind + ": " + ev.Message
+}
 
 type Function struct {
+	Name       string // 함수 이름 (익명 함수면 빈 문자열)
 	Parameters []*ast.Identifier
+	Defaults   map[string]ast.Expression // 호출 시 인자가 없으면 평가할 기본값
+	Rest       *ast.Identifier           // 가변 파라미터 (없으면 nil)
 	Body       *ast.BlockStatement
 	Env        *Environment
 }
@@ -71,14 +145,12 @@ func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
 func (f *Function) Inspect() string {
 	var out bytes.Buffer
 
-	params := []string{}
-	for _, p := range f.Parameters {
-		params = append(params, p.String())
-	}
-
 	out.WriteString("fn")
+	if f.Name != "" {
+		out.WriteString(" " + f.Name)
+	}
 	out.WriteString("(")
-	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(ast.FormatParameters(f.Parameters, f.Defaults, f.Rest))
 	out.WriteString(") {\n")
 	out.WriteString(f.Body.String())
 	out.WriteString("\n}")
@@ -103,6 +175,7 @@ func (b *Builtin) Inspect() string  { return "builtin function" }
 
 type Array struct {
 	Elements []Object
+	Frozen   bool // freeze() 로 얼린 배열인지 여부 (요소를 바꾸는 연산이 생기면 검사해야 함)
 }
 
 func (ao *Array) Type() ObjectType { return ARRAY_OBJ }
@@ -163,7 +236,8 @@ type HashPair struct {
 }
 
 type Hash struct {
-	Pairs map[HashKey]HashPair
+	Pairs  map[HashKey]HashPair
+	Frozen bool // freeze() 로 얼린 해시인지 여부 (키와 값을 바꾸는 연산이 생기면 검사해야 함)
 }
 
 func (h *Hash) Type() ObjectType { return HASH_OBJ }
@@ -181,3 +255,40 @@ func (h *Hash) Inspect() string {
 
 	return out.String()
 }
+
+// Quote : quote() 로 평가되지 않은 채 감싸진 AST 노드
+type Quote struct {
+	Node ast.Node
+}
+
+func (q *Quote) Type() ObjectType { return QUOTE_OBJ }
+func (q *Quote) Inspect() string  { return "QUOTE(" + q.Node.String() + ")" }
+
+// Macro : 매크로 확장 단계에서만 쓰이는 매크로 정의
+type Macro struct {
+	Parameters []*ast.Identifier
+	Body       *ast.BlockStatement
+	Env        *Environment
+}
+
+func (m *Macro) Type() ObjectType { return MACRO_OBJ }
+func (m *Macro) Inspect() string {
+	var out bytes.Buffer
+
+	out.WriteString("macro(")
+	out.WriteString(ast.FormatParameters(m.Parameters, nil, nil))
+	out.WriteString(") {\n")
+	out.WriteString(m.Body.String())
+	out.WriteString("\n}")
+
+	return out.String()
+}
+
+// Module : import 로 불러온 모듈, 모듈이 export 한 이름만 밖에서 접근할 수 있음
+type Module struct {
+	Name    string // import 에 쓴 경로
+	Exports map[string]Object
+}
+
+func (m *Module) Type() ObjectType { return MODULE_OBJ }
+func (m *Module) Inspect() string  { return "module(" + m.Name + ")" }
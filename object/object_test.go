@@ -20,3 +20,22 @@ func TestStringHashKey(t *testing.T) {
 		t.Errorf("strings with same content have different hash keys")
 	}
 }
+
+func TestFloatInspect(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{2, "2.0"},
+		{3.5, "3.5"},
+		{-0.25, "-0.25"},
+		{1e21, "1e+21"},
+	}
+
+	for _, tt := range tests {
+		f := &Float{Value: tt.value}
+		if f.Inspect() != tt.expected {
+			t.Errorf("Float.Inspect() wrong. expected=%q, got=%q", tt.expected, f.Inspect())
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"fmt"
+	"interpreter-go/token"
+)
+
+// ParseError : 파싱 에러 하나 (위치, 기대했던 토큰, 실제 토큰, 힌트)
+type ParseError struct {
+	Pos      token.Position
+	Message  string
+	Expected []token.TokenType // 기대했던 토큰 (특정 토큰을 기대한 에러가 아니면 nil)
+	Actual   token.Token       // 에러 위치에서 실제로 만난 토큰
+	Hint     string            // 에러 원인을 찾는데 도움이 되는 추가 정보 ex) unclosed `{` opened at 3:10
+}
+
+// Error : file.mk:12:7: 메시지 (힌트) 형태로 출력
+func (e *ParseError) Error() string {
+	msg := e.Pos.String() + ": " + e.Message
+	if e.Hint != "" {
+		msg += " (" + e.Hint + ")"
+	}
+	return msg
+}
+
+// 닫히지 않은 괄호에 대한 힌트
+func unclosedHint(open token.Token) string {
+	return fmt.Sprintf("unclosed `%s` opened at %d:%d", open.Literal, open.Pos.Line, open.Pos.Column)
+}
@@ -6,15 +6,20 @@ import (
 	"interpreter-go/lexer"
 	"interpreter-go/token"
 	"strconv"
+	"strings"
 )
 
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN      // =, +=, -=, *=, /=
+	LOGICAL_OR  // ||
+	LOGICAL_AND // &&
 	EQUALS      // == 또는 !=
-	LESSGREATER // > 또는 <
+	LESSGREATER // >, <, >= 또는 <=
+	PIPELINE    // x |> f()
 	SUM         // +
-	PRODUCT     // *
+	PRODUCT     // *, / 또는 %
 	PREFIX      // -X 또는 !X
 	CALL        // myFunction(X)
 	INDEX       // array[index]
@@ -22,16 +27,28 @@ const (
 
 // 연산자들의 우선순위 지정
 var precedences = map[token.TokenType]int{
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LT:       LESSGREATER,
-	token.GT:       LESSGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
-	token.LPAREN:   CALL,
-	token.LBRACKET: INDEX,
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.OR:              LOGICAL_OR,
+	token.AND:             LOGICAL_AND,
+	token.EQ:              EQUALS,
+	token.NOT_EQ:          EQUALS,
+	token.LT:              LESSGREATER,
+	token.GT:              LESSGREATER,
+	token.LT_EQ:           LESSGREATER,
+	token.GT_EQ:           LESSGREATER,
+	token.PIPELINE:        PIPELINE,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.SLASH:           PRODUCT,
+	token.ASTERISK:        PRODUCT,
+	token.PERCENT:         PRODUCT,
+	token.LPAREN:          CALL,
+	token.LBRACKET:        INDEX,
+	token.DOT:             INDEX,
 }
 
 type (
@@ -41,12 +58,19 @@ type (
 )
 
 type Parser struct {
-	l      *lexer.Lexer
-	errors []string
+	l         *lexer.Lexer
+	errors    []*ParseError
+	panicking bool // 현재 구문에서 이미 에러가 나서 다음 구문 경계까지 건너뛰어야 하는지 여부
+	depth     int  // currentToken까지 닫히지 않은 '{'의 수 (에러 복구 시 구문 경계를 찾기 위해 사용)
+	loopDepth int  // 현재 파싱 중인 반복문의 중첩 수 (반복문 밖의 break, continue 검사용)
 
 	currentToken token.Token
 	peekToken    token.Token
 
+	comments   []*ast.CommentGroup // 지금까지 읽은 모든 주석
+	currentDoc *ast.CommentGroup   // currentToken 바로 윗줄에 붙어있는 주석
+	peekDoc    *ast.CommentGroup   // peekToken 바로 윗줄에 붙어있는 주석
+
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
 }
@@ -54,7 +78,7 @@ type Parser struct {
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		l:      l,
-		errors: []string{},
+		errors: []*ParseError{},
 	}
 	// 렉서를 읽어서 peekToken 세팅 (curToken은 최초 peekToken이 nil이라서 비어있음)
 	p.nextToken()
@@ -65,6 +89,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	p.registerPrefix(token.IDENT, p.parseIdentifier)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
+	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
 
@@ -81,8 +106,19 @@ func New(l *lexer.Lexer) *Parser {
 	// function 파싱 함수 추가
 	p.registerPrefix(token.FUNCTION, p.parseFunctionExpression)
 
+	// match 파싱 함수 추가
+	p.registerPrefix(token.MATCH, p.parseMatchExpression)
+	p.registerPrefix(token.TRY, p.parseTryExpression)
+	p.registerPrefix(token.MACRO, p.parseMacroLiteral)
+
+	// 람다 파싱 함수 추가 (파라미터가 없으면 || 토큰으로 읽힘)
+	p.registerPrefix(token.PIPE, p.parseLambdaExpression)
+	p.registerPrefix(token.OR, p.parseLambdaExpression)
+
 	// String 파싱 함수 추가
 	p.registerPrefix(token.STRING, p.parseStringLiteral)
+	p.registerPrefix(token.RAW_STRING, p.parseStringLiteral)
+	p.registerPrefix(token.INTERP_HEAD, p.parseInterpolatedString)
 
 	// Array 파싱 함수 추가
 	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
@@ -100,6 +136,21 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.NOT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
+	p.registerInfix(token.PERCENT, p.parseInfixExpression)
+	p.registerInfix(token.LT_EQ, p.parseInfixExpression)
+	p.registerInfix(token.GT_EQ, p.parseInfixExpression)
+	p.registerInfix(token.AND, p.parseInfixExpression)
+	p.registerInfix(token.OR, p.parseInfixExpression)
+
+	// 대입 표현식 파싱 함수 추가
+	p.registerInfix(token.ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.PLUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.MINUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.ASTERISK_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.SLASH_ASSIGN, p.parseAssignExpression)
+
+	// 파이프라인 파싱 함수 추가
+	p.registerInfix(token.PIPELINE, p.parsePipelineExpression)
 
 	// 함수 호출 표현식 파싱 함수 추가
 	p.registerInfix(token.LPAREN, p.parseCallExpression)
@@ -107,6 +158,9 @@ func New(l *lexer.Lexer) *Parser {
 	// Array 인덱스 파싱 함수
 	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
 
+	// 모듈 멤버 접근 파싱 함수
+	p.registerInfix(token.DOT, p.parseMemberExpression)
+
 	return p
 }
 
@@ -119,8 +173,20 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 
 	value, err := strconv.ParseInt(p.currentToken.Literal, 0, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as interger", p.currentToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.addError(p.currentToken.Pos, "could not parse %q as integer", p.currentToken.Literal)
+		return nil
+	}
+
+	lit.Value = value
+	return lit
+}
+
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.currentToken}
+
+	value, err := strconv.ParseFloat(p.currentToken.Literal, 64)
+	if err != nil {
+		p.addError(p.currentToken.Pos, "could not parse %q as float", p.currentToken.Literal)
 		return nil
 	}
 
@@ -146,11 +212,12 @@ func (p *Parser) parseBoolean() ast.Expression {
 }
 
 func (p *Parser) parseGroupedExpression() ast.Expression {
+	open := p.currentToken
 	p.nextToken()
 
 	expression := p.parseExpression(LOWEST)
 
-	if !p.expectPeek(token.RPAREN) {
+	if !p.expectClose(token.RPAREN, open) {
 		return nil
 	}
 
@@ -164,6 +231,7 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	if !p.expectPeek(token.LPAREN) {
 		return nil
 	}
+	open := p.currentToken
 
 	// '(' 다음에 조건이 나오니까 nextToken 호출
 	p.nextToken()
@@ -172,7 +240,7 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	expression.Condition = p.parseExpression(LOWEST)
 
 	// 조건이 끝나면 ')'가 나와야함
-	if !p.expectPeek(token.RPAREN) {
+	if !p.expectClose(token.RPAREN, open) {
 		return nil
 	}
 
@@ -197,6 +265,56 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	return expression
 }
 
+// try { ... } catch (e) { ... } finally { ... }
+// catch 의 파라미터는 생략할 수 있고, catch 와 finally 중 하나는 있어야 함
+func (p *Parser) parseTryExpression() ast.Expression {
+	expression := &ast.TryExpression{Token: p.currentToken}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	expression.Block = p.parseBlockStatement()
+
+	if p.peekTokenIs(token.CATCH) {
+		p.nextToken()
+
+		if p.peekTokenIs(token.LPAREN) {
+			p.nextToken()
+			open := p.currentToken
+
+			if !p.expectPeek(token.IDENT) {
+				return nil
+			}
+			expression.CatchParam = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+			if !p.expectClose(token.RPAREN, open) {
+				return nil
+			}
+		}
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+		expression.Catch = p.parseBlockStatement()
+	}
+
+	if p.peekTokenIs(token.FINALLY) {
+		p.nextToken()
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+		expression.Finally = p.parseBlockStatement()
+	}
+
+	if expression.Catch == nil && expression.Finally == nil {
+		p.addError(expression.Token.Pos, "try without catch or finally")
+		return nil
+	}
+
+	return expression
+}
+
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{Token: p.currentToken}
 	block.Statements = []ast.Statement{}
@@ -207,12 +325,26 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	// '{' 이후에는 '}'를 만날 때 까지 if문이 true일 때의 구문임
 	for !p.currentTokenIs(token.RBRACE) && !p.currentTokenIs(token.EOF) {
 		// for문을 통해 구문별로 파싱
+		depth := p.statementDepth()
 		statement := p.parseStatement()
-		if statement != nil {
+		if p.panicking {
+			p.synchronize(depth)
+		} else if statement != nil {
 			block.Statements = append(block.Statements, statement)
 		}
 		p.nextToken()
 	}
+	block.Rbrace = p.currentToken
+
+	if p.currentTokenIs(token.EOF) {
+		p.appendError(&ParseError{
+			Pos:      p.currentToken.Pos,
+			Message:  "Expected next token to be }, got EOF instead",
+			Expected: []token.TokenType{token.RBRACE},
+			Actual:   p.currentToken,
+			Hint:     unclosedHint(block.Token),
+		})
+	}
 
 	return block
 }
@@ -221,53 +353,149 @@ func (p *Parser) parseFunctionExpression() ast.Expression {
 
 	lit := &ast.FunctionLiteral{Token: p.currentToken}
 
+	if !p.parseFunctionBody(lit) {
+		return nil
+	}
+
+	return lit
+}
+
+// macro(params) { ... } 형태의 매크로 리터럴, 파라미터 목록과 본문은 함수 리터럴과 같음
+// 매크로의 인자는 평가되지 않은 AST 이므로 기본값과 가변 파라미터는 쓸 수 없음
+func (p *Parser) parseMacroLiteral() ast.Expression {
+	macro := &ast.MacroLiteral{Token: p.currentToken}
+
+	lit := &ast.FunctionLiteral{Token: p.currentToken}
+	if !p.parseFunctionBody(lit) {
+		return nil
+	}
+	if len(lit.Defaults) > 0 || lit.Rest != nil {
+		p.addError(macro.Token.Pos, "macro parameters cannot have defaults or rest")
+		return nil
+	}
+
+	macro.Parameters = lit.Parameters
+	macro.Body = lit.Body
+
+	return macro
+}
+
+// fn name(params) { ... } 형태의 함수 선언문
+func (p *Parser) parseFunctionStatement() ast.Statement {
+	statement := &ast.FunctionStatement{Token: p.currentToken}
+
+	p.nextToken()
+	statement.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	// 함수명 이후는 함수 리터럴과 같음
+	statement.Function = &ast.FunctionLiteral{Token: statement.Token, Name: statement.Name.Value}
+	if !p.parseFunctionBody(statement.Function) {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+// 파라미터 목록부터 함수 본문까지 파싱 (peekToken이 여는 소괄호)
+func (p *Parser) parseFunctionBody(lit *ast.FunctionLiteral) bool {
 	// 여는 소괄호(=파라미터 시작지점) 이 아니면 리턴
 	if !p.expectPeek(token.LPAREN) {
-		return nil
+		return false
 	}
 
-	lit.Parameters = p.parseFunctionParameters()
+	if !p.parseFunctionParameters(lit, token.RPAREN) {
+		return false
+	}
 
 	if !p.expectPeek(token.LBRACE) {
-		return nil
+		return false
 	}
 
+	// 함수 본문은 바깥 반복문과 별개이므로 함수 안에서 바깥 반복문을 break 할 수 없음
+	loopDepth := p.loopDepth
+	p.loopDepth = 0
 	lit.Body = p.parseBlockStatement()
+	p.loopDepth = loopDepth
 
-	return lit
+	return true
 }
 
-// prefix가 FUNCTION 토큰일때 소괄호까지 토큰 진행 후 호출됨
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	identifiers := []*ast.Identifier{}
+// |x, y| x + y 형태의 람다, 본문은 표현식 하나이고 fn(x, y) { x + y } 와 같은 함수 리터럴로 만들어짐
+func (p *Parser) parseLambdaExpression() ast.Expression {
+	lit := &ast.FunctionLiteral{Token: p.currentToken}
 
-	// 파라미터가 비어있으면 여는 소괄호 바로 뒤에 닫는 소괄호가 나올 수 있음
-	if p.peekTokenIs(token.RPAREN) {
-		p.nextToken() // 닫힌 소괄호 스킵
-		return identifiers
+	// || 는 파라미터가 없는 람다
+	if p.currentTokenIs(token.OR) {
+		lit.Parameters = []*ast.Identifier{}
+	} else if !p.parseFunctionParameters(lit, token.PIPE) {
+		return nil
 	}
 
-	// 소괄호 내부의 파라미터부터 토큰 진행하기 위한 호출
 	p.nextToken()
+	body := &ast.ExpressionStatement{Token: p.currentToken}
 
-	// 첫 파라미터 호출 후 저장
-	identifier := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
-	identifiers = append(identifiers, identifier)
+	// 함수 본문은 바깥 반복문과 별개이므로 함수 안에서 바깥 반복문을 break 할 수 없음
+	loopDepth := p.loopDepth
+	p.loopDepth = 0
+	body.Expression = p.parseExpression(LOWEST)
+	p.loopDepth = loopDepth
 
-	// 두번 째 파라미터가 있는지 콤마로 식별
-	for p.peekTokenIs(token.COMMA) {
-		p.nextToken() // 호출을 통해 토큰을 콤마로 진행
-		p.nextToken() // 호출을 통해 토큰을 다음 파라미터로 진행
+	lit.Body = &ast.BlockStatement{Token: body.Token, Statements: []ast.Statement{body}}
+
+	return lit
+}
+
+// 여는 괄호(소괄호 혹은 람다의 |) 토큰까지 진행 후 호출됨
+// 파라미터는 a, 기본값이 있는 b = 10, 가변 파라미터 ...rest 형태
+// 기본값이 있는 파라미터 뒤에는 기본값이 없는 파라미터가 올 수 없고, 가변 파라미터는 마지막에만 올 수 있음
+func (p *Parser) parseFunctionParameters(lit *ast.FunctionLiteral, end token.TokenType) bool {
+	lit.Parameters = []*ast.Identifier{}
+	open := p.currentToken
+
+	// 파라미터가 비어있으면 여는 괄호 바로 뒤에 닫는 괄호가 나올 수 있음
+	for !p.peekTokenIs(end) {
+		if p.peekTokenIs(token.ELLIPSIS) {
+			p.nextToken()
+			if !p.expectPeek(token.IDENT) {
+				return false
+			}
+			lit.Rest = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+			if !p.peekTokenIs(end) {
+				p.addError(p.peekToken.Pos, "rest parameter must be last")
+				return false
+			}
+			break
+		}
+
+		if !p.expectPeek(token.IDENT) {
+			return false
+		}
 		identifier := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
-		identifiers = append(identifiers, identifier)
-	}
+		lit.Parameters = append(lit.Parameters, identifier)
+
+		if p.peekTokenIs(token.ASSIGN) {
+			p.nextToken()
+			p.nextToken()
+			if lit.Defaults == nil {
+				lit.Defaults = make(map[string]ast.Expression)
+			}
+			lit.Defaults[identifier.Value] = p.parseExpression(LOWEST)
+		} else if len(lit.Defaults) > 0 {
+			p.addError(identifier.Pos(), "parameter %s without default follows parameter with default", identifier.Value)
+			return false
+		}
 
-	// 마지막 파라미터 수집 후 닫는 소괄호가 안나오면 잘못된 문법이므로 nil 리턴
-	if !p.expectPeek(token.RPAREN) {
-		return nil
+		if !p.expectSeparator(end, open) {
+			return false
+		}
 	}
 
-	return identifiers
+	// 마지막 파라미터 수집 후 닫는 괄호가 안나오면 잘못된 문법
+	return p.expectClose(end, open)
 }
 
 func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
@@ -284,24 +512,145 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	return expression
 }
 
+// 대입 표현식은 우결합이라서 우측은 한 단계 낮은 우선순위로 파싱 ex) a = b = 5 -> (a = (b = 5))
+// 좌측 파싱이 이미 실패했으면 좌측이 nil 이거나 일부만 채워진 노드이므로 에러를 더하지 않음
+func (p *Parser) parseAssignExpression(left ast.Expression) ast.Expression {
+	if left == nil || p.panicking {
+		return nil
+	}
+
+	name, ok := left.(*ast.Identifier)
+	if !ok {
+		p.addError(left.Pos(), "invalid assignment target %s", left.String())
+		return nil
+	}
+
+	expression := &ast.AssignExpression{
+		Token:    p.currentToken,
+		Name:     name,
+		Operator: p.currentToken.Literal,
+	}
+
+	p.nextToken()
+	expression.Value = p.parseExpression(ASSIGN - 1)
+
+	return expression
+}
+
+// x |> f(a, b) 는 f(x, a, b) 호출식으로, x |> f 는 f(x) 호출식으로 바꿔서 파싱
+func (p *Parser) parsePipelineExpression(left ast.Expression) ast.Expression {
+	pipe := p.currentToken
+	precedence := p.curPrecedence()
+	p.nextToken()
+
+	right := p.parseExpression(precedence)
+	if right == nil {
+		return nil
+	}
+
+	if call, ok := right.(*ast.CallExpression); ok {
+		call.Arguments = append([]ast.Expression{left}, call.Arguments...)
+		return call
+	}
+	return &ast.CallExpression{Token: pipe, Function: right, Arguments: []ast.Expression{left}}
+}
+
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 	expression := &ast.CallExpression{Token: p.currentToken, Function: function}
 	expression.Arguments = p.parseExpressionList(token.RPAREN)
+	expression.Rparen = p.currentToken
 	return expression
 }
 
+// Errors : 렉서 에러를 먼저, 그 다음 파서 에러를 메시지로 리턴
 func (p *Parser) Errors() []string {
+	errors := append([]string{}, p.l.Errors()...)
+	for _, err := range p.errors {
+		errors = append(errors, err.Error())
+	}
+	return errors
+}
+
+// ParseErrors : 파서 에러 목록 (렉서 에러는 포함하지 않음)
+func (p *Parser) ParseErrors() []*ParseError {
 	return p.errors
 }
 
+// 에러를 저장하고 패닉 모드로 진입
+// 패닉 모드에서는 다음 구문 경계까지 에러를 더 남기지 않음 (오타 하나에 에러가 연쇄적으로 나오지 않도록)
+func (p *Parser) appendError(err *ParseError) {
+	if p.panicking {
+		return
+	}
+	p.panicking = true
+	p.errors = append(p.errors, err)
+}
+
+// 에러 메시지 앞에 위치를 붙여서 저장 ex) file.mk:12:7: ...
+func (p *Parser) addError(pos token.Position, format string, a ...interface{}) {
+	p.appendError(&ParseError{Pos: pos, Message: fmt.Sprintf(format, a...), Actual: p.currentToken})
+}
+
 func (p *Parser) peekError(t token.TokenType) {
-	msg := fmt.Sprintf("Expected next token to be %s, got %s instead", t, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
+	p.expectationError(p.peekToken, "", t)
+}
+
+// 기대한 토큰 대신 actual 토큰을 만났을 때의 에러
+func (p *Parser) expectationError(actual token.Token, hint string, expected ...token.TokenType) {
+	// ILLEGAL 토큰은 렉서가 이미 에러를 남겼으므로 중복해서 남기지 않음
+	if actual.Type == token.ILLEGAL {
+		p.panicking = true
+		return
+	}
+
+	names := []string{}
+	for _, t := range expected {
+		names = append(names, string(t))
+	}
+
+	p.appendError(&ParseError{
+		Pos:      actual.Pos,
+		Message:  fmt.Sprintf("Expected next token to be %s, got %s instead", strings.Join(names, " or "), actual.Type),
+		Expected: expected,
+		Actual:   actual,
+		Hint:     hint,
+	})
 }
 
 func (p *Parser) nextToken() {
 	p.currentToken = p.peekToken
-	p.peekToken = p.l.NextToken()
+	p.currentDoc = p.peekDoc
+	switch p.currentToken.Type {
+	case token.LBRACE:
+		p.depth++
+	case token.RBRACE:
+		p.depth--
+	}
+	p.peekToken, p.peekDoc = p.readToken()
+}
+
+// 주석 토큰은 묶어서 p.comments에 저장하고 다음 토큰을 리턴
+// 다음 토큰 바로 윗줄에서 끝나는 주석 묶음은 그 토큰의 문서 주석으로 함께 리턴
+func (p *Parser) readToken() (token.Token, *ast.CommentGroup) {
+	prevLine := p.currentToken.End.Line
+	var group *ast.CommentGroup
+
+	tok := p.l.NextToken()
+	for tok.Type == token.COMMENT {
+		// 빈 줄이 끼어 있으면 새로운 묶음 시작
+		if group == nil || group.End().Line+1 < tok.Pos.Line {
+			group = &ast.CommentGroup{}
+			p.comments = append(p.comments, group)
+		}
+		group.List = append(group.List, &ast.Comment{Token: tok})
+		tok = p.l.NextToken()
+	}
+
+	// 이전 토큰과 같은 줄에서 시작하는 주석은 이전 토큰에 대한 주석이므로 제외
+	if group != nil && group.End().Line+1 >= tok.Pos.Line && group.Pos().Line > prevLine {
+		return tok, group
+	}
+	return tok, nil
 }
 
 func (p *Parser) ParseProgram() *ast.Program {
@@ -309,23 +658,86 @@ func (p *Parser) ParseProgram() *ast.Program {
 	program.Statements = []ast.Statement{}
 
 	for p.currentToken.Type != token.EOF {
+		depth := p.statementDepth()
 		statement := p.parseStatement()
-		if statement != nil {
+		if p.panicking {
+			// 에러가 난 구문은 버리고 다음 구문부터 다시 파싱
+			p.synchronize(depth)
+		} else if statement != nil {
 			program.Statements = append(program.Statements, statement)
 		}
 		// parseStatement에 선언된 문법이 아니면 토큰 스킵
 		p.nextToken()
 	}
+	program.Comments = p.comments
 
 	return program
 }
 
+// 구문을 시작하는 토큰 (에러 복구 시 여기서부터 다시 파싱)
+var statementStarts = map[token.TokenType]bool{
+	token.LET:      true,
+	token.CONST:    true,
+	token.RETURN:   true,
+	token.WHILE:    true,
+	token.BREAK:    true,
+	token.CONTINUE: true,
+	token.FOR:      true,
+	token.FUNCTION: true,
+	token.THROW:    true,
+	token.IMPORT:   true,
+	token.EXPORT:   true,
+}
+
+// 구문이 시작되는 위치의 '{' 깊이 (구문이 '{'로 시작하면 그 '{'는 구문 안쪽으로 봄)
+func (p *Parser) statementDepth() int {
+	if p.currentTokenIs(token.LBRACE) {
+		return p.depth - 1
+	}
+	return p.depth
+}
+
+// 패닉 모드 해제: 에러가 난 구문의 남은 토큰을 구문 경계(';' 혹은 '}' 직전)까지 건너뜀
+// 구문 안쪽의 {...}는 통째로 건너뛰고, 구문과 같은 깊이에서 다음 구문을 시작하는 예약어를 만나도 멈춤
+func (p *Parser) synchronize(depth int) {
+	for !p.currentTokenIs(token.EOF) && p.depth >= depth {
+		if p.depth == depth {
+			if p.currentTokenIs(token.SEMICOLON) || p.peekTokenIs(token.RBRACE) ||
+				p.peekTokenIs(token.EOF) || statementStarts[p.peekToken.Type] {
+				break
+			}
+		}
+		p.nextToken()
+	}
+	p.panicking = false
+}
+
 func (p *Parser) parseStatement() ast.Statement {
 	switch p.currentToken.Type {
 	case token.LET:
 		return p.parseLetStatement()
+	case token.CONST:
+		return p.parseConstStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.WHILE:
+		return p.parseWhileStatement()
+	case token.FOR:
+		return p.parseForInStatement()
+	case token.FUNCTION:
+		// fn 다음에 이름이 오면 함수 선언문, 아니면 함수 리터럴 표현식
+		if p.peekTokenIs(token.IDENT) {
+			return p.parseFunctionStatement()
+		}
+		return p.parseExpressionStatement()
+	case token.BREAK, token.CONTINUE:
+		return p.parseLoopControlStatement()
+	case token.THROW:
+		return p.parseThrowStatement()
+	case token.IMPORT:
+		return p.parseImportStatement()
+	case token.EXPORT:
+		return p.parseExportStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -334,16 +746,25 @@ func (p *Parser) parseStatement() ast.Statement {
 // Let으로 변수할당하는 코드 한줄을 받으면 호출
 func (p *Parser) parseLetStatement() *ast.LetStatement {
 	// LetStatement 인스턴스 생성
-	statement := &ast.LetStatement{Token: p.currentToken}
+	statement := &ast.LetStatement{Token: p.currentToken, Doc: p.currentDoc}
 
+	switch {
+	// 구조 분해 할당 ex) let [a, b] = arr; let {name, age} = person;
+	case p.peekTokenIs(token.LBRACKET):
+		p.nextToken()
+		statement.Pattern = p.parseArrayPattern()
+	case p.peekTokenIs(token.LBRACE):
+		p.nextToken()
+		statement.Pattern = p.parseHashPattern()
 	// expectPeek으로 다음 토큰이 IDENT(=변수명)인지 확인
-	if !p.expectPeek(token.IDENT) {
+	case p.expectPeek(token.IDENT):
+		// 변수명이 맞으면 Name을 Identifier로 초기화
+		statement.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	}
+	if statement.Name == nil && statement.Pattern == nil {
 		return nil
 	}
 
-	// 변수명이 맞으면 Name을 Identifier로 초기화
-	statement.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
-
 	if !p.expectPeek(token.ASSIGN) {
 		return nil
 	}
@@ -352,6 +773,11 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 
 	statement.Value = p.parseExpression(LOWEST)
 
+	// let으로 바로 바인딩한 함수 리터럴은 변수명을 함수 이름으로 사용
+	if fl, ok := statement.Value.(*ast.FunctionLiteral); ok && statement.Name != nil {
+		fl.Name = statement.Name.Value
+	}
+
 	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
@@ -359,6 +785,197 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 	return statement
 }
 
+// const NAME = expr; 형태로 let 과 같은 LetStatement 를 만들고 토큰으로 구분함
+// 상수는 이름 하나에만 바인딩할 수 있음 (구조 분해 불가)
+func (p *Parser) parseConstStatement() ast.Statement {
+	if !p.peekTokenIs(token.IDENT) {
+		p.peekError(token.IDENT)
+		return nil
+	}
+
+	statement := p.parseLetStatement()
+	if statement == nil {
+		return nil
+	}
+	return statement
+}
+
+// 패턴 하나를 파싱 (현재 토큰이 패턴의 시작)
+// 식별자(_ 는 와일드카드), 리터럴, 배열 패턴, 해시 패턴
+func (p *Parser) parsePattern() ast.Pattern {
+	switch p.currentToken.Type {
+	case token.IDENT:
+		return &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	case token.LBRACKET:
+		return p.parseArrayPattern()
+	case token.LBRACE:
+		return p.parseHashPattern()
+	case token.INT, token.FLOAT, token.STRING, token.RAW_STRING, token.TRUE, token.FALSE:
+		return &ast.LiteralPattern{Value: p.prefixParseFns[p.currentToken.Type]()}
+	case token.MINUS:
+		// 음수 리터럴 ex) -1
+		if p.peekTokenIs(token.INT) || p.peekTokenIs(token.FLOAT) {
+			return &ast.LiteralPattern{Value: p.parsePrefixExpression()}
+		}
+	}
+
+	if p.currentToken.Type != token.ILLEGAL {
+		p.addError(p.currentToken.Pos, "unexpected %s in pattern", p.currentToken.Type)
+	} else {
+		p.panicking = true
+	}
+	return nil
+}
+
+// [a, b, ...rest] 형태 ('[' 토큰에서 호출됨)
+func (p *Parser) parseArrayPattern() ast.Pattern {
+	pattern := &ast.ArrayPattern{Token: p.currentToken}
+
+	for !p.peekTokenIs(token.RBRACKET) {
+		// ...rest 는 마지막에만 올 수 있음
+		if p.peekTokenIs(token.ELLIPSIS) {
+			p.nextToken()
+			if !p.expectPeek(token.IDENT) {
+				return nil
+			}
+			pattern.Rest = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+			if !p.peekTokenIs(token.RBRACKET) {
+				p.addError(p.peekToken.Pos, "rest element must be last in array pattern")
+				return nil
+			}
+			break
+		}
+
+		p.nextToken()
+		element := p.parsePattern()
+		if element == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, element)
+
+		if !p.expectSeparator(token.RBRACKET, pattern.Token) {
+			return nil
+		}
+	}
+
+	if !p.expectClose(token.RBRACKET, pattern.Token) {
+		return nil
+	}
+	pattern.Rbrack = p.currentToken
+
+	return pattern
+}
+
+// 해시 패턴에 키로 쓸 수 있는 토큰
+var hashPatternKeys = map[token.TokenType]bool{
+	token.STRING:     true,
+	token.RAW_STRING: true,
+	token.INT:        true,
+	token.TRUE:       true,
+	token.FALSE:      true,
+}
+
+// {name, "age": a} 형태 ('{' 토큰에서 호출됨)
+// 식별자 키는 같은 이름의 문자열 키이고, 값 패턴을 생략하면 같은 이름의 변수에 바인딩
+func (p *Parser) parseHashPattern() ast.Pattern {
+	pattern := &ast.HashPattern{Token: p.currentToken}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		pair := &ast.HashPatternPair{}
+
+		switch {
+		case p.currentTokenIs(token.IDENT):
+			pair.Key = &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal}
+			if !p.peekTokenIs(token.COLON) {
+				pair.Value = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+			}
+		case hashPatternKeys[p.currentToken.Type]:
+			pair.Key = p.prefixParseFns[p.currentToken.Type]()
+		default:
+			p.expectationError(p.currentToken, "", token.IDENT, token.STRING)
+			return nil
+		}
+
+		if pair.Value == nil {
+			if !p.expectPeek(token.COLON) {
+				return nil
+			}
+			p.nextToken()
+			if pair.Value = p.parsePattern(); pair.Value == nil {
+				return nil
+			}
+		}
+		pattern.Pairs = append(pattern.Pairs, pair)
+
+		if !p.expectSeparator(token.RBRACE, pattern.Token) {
+			return nil
+		}
+	}
+
+	if !p.expectClose(token.RBRACE, pattern.Token) {
+		return nil
+	}
+	pattern.Rbrace = p.currentToken
+
+	return pattern
+}
+
+// match (subject) { pattern if guard => body, ... } 형태
+func (p *Parser) parseMatchExpression() ast.Expression {
+	exp := &ast.MatchExpression{Token: p.currentToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	open := p.currentToken
+
+	p.nextToken()
+	exp.Subject = p.parseExpression(LOWEST)
+
+	if !p.expectClose(token.RPAREN, open) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	brace := p.currentToken
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		arm := &ast.MatchArm{Pattern: p.parsePattern()}
+		if arm.Pattern == nil {
+			return nil
+		}
+
+		if p.peekTokenIs(token.IF) {
+			p.nextToken()
+			p.nextToken()
+			arm.Guard = p.parseExpression(LOWEST)
+		}
+
+		if !p.expectPeek(token.ARROW) {
+			return nil
+		}
+
+		p.nextToken()
+		arm.Body = p.parseExpression(LOWEST)
+		exp.Arms = append(exp.Arms, arm)
+
+		if !p.expectSeparator(token.RBRACE, brace) {
+			return nil
+		}
+	}
+
+	if !p.expectClose(token.RBRACE, brace) {
+		return nil
+	}
+	exp.Rbrace = p.currentToken
+
+	return exp
+}
+
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	statement := &ast.ReturnStatement{Token: p.currentToken}
 
@@ -373,6 +990,174 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return statement
 }
 
+func (p *Parser) parseThrowStatement() ast.Statement {
+	statement := &ast.ThrowStatement{Token: p.currentToken}
+
+	p.nextToken()
+
+	statement.Value = p.parseExpression(LOWEST)
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+// import "path" as name; 형태 (as 는 예약어가 아니라 이 자리에서만 쓰이는 식별자)
+func (p *Parser) parseImportStatement() ast.Statement {
+	statement := &ast.ImportStatement{Token: p.currentToken}
+
+	if !p.expectPeek(token.STRING) {
+		return nil
+	}
+	statement.Path = &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	if p.peekTokenIs(token.IDENT) && p.peekToken.Literal == "as" {
+		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		statement.Alias = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+// export let name = ...; 혹은 export fn name() { ... } 형태로 최상위에서만 쓸 수 있음
+func (p *Parser) parseExportStatement() ast.Statement {
+	statement := &ast.ExportStatement{Token: p.currentToken}
+
+	if p.depth > 0 {
+		p.addError(statement.Token.Pos, "export must be at top level")
+		return nil
+	}
+
+	p.nextToken()
+
+	switch {
+	case (p.currentTokenIs(token.LET) || p.currentTokenIs(token.CONST)) && p.peekTokenIs(token.IDENT):
+		letStatement := p.parseLetStatement()
+		if letStatement == nil {
+			return nil
+		}
+		statement.Declaration, statement.Name = letStatement, letStatement.Name
+	case p.currentTokenIs(token.FUNCTION) && p.peekTokenIs(token.IDENT):
+		function, ok := p.parseFunctionStatement().(*ast.FunctionStatement)
+		if !ok {
+			return nil
+		}
+		statement.Declaration, statement.Name = function, function.Name
+	default:
+		p.addError(p.currentToken.Pos, "export requires a named let, const or fn declaration, got %s", p.currentToken.Type)
+		return nil
+	}
+
+	return statement
+}
+
+func (p *Parser) parseWhileStatement() ast.Statement {
+	statement := &ast.WhileStatement{Token: p.currentToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	open := p.currentToken
+
+	p.nextToken()
+	statement.Condition = p.parseExpression(LOWEST)
+
+	if !p.expectClose(token.RPAREN, open) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.loopDepth++
+	statement.Body = p.parseBlockStatement()
+	p.loopDepth--
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+// for (x in arr) 혹은 for (k, v in hash) 형태
+func (p *Parser) parseForInStatement() ast.Statement {
+	statement := &ast.ForInStatement{Token: p.currentToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	open := p.currentToken
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	statement.Value = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	// 변수가 두 개면 첫번째 변수는 인덱스 혹은 키
+	if p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		statement.Key = statement.Value
+		statement.Value = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	}
+
+	if !p.expectPeek(token.IN) {
+		return nil
+	}
+
+	p.nextToken()
+	statement.Iterable = p.parseExpression(LOWEST)
+
+	if !p.expectClose(token.RPAREN, open) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.loopDepth++
+	statement.Body = p.parseBlockStatement()
+	p.loopDepth--
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+// break, continue 구문은 반복문 안에서만 사용할 수 있음
+func (p *Parser) parseLoopControlStatement() ast.Statement {
+	tok := p.currentToken
+	if p.loopDepth == 0 {
+		p.addError(tok.Pos, "%s outside of loop", tok.Literal)
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	if tok.Type == token.BREAK {
+		return &ast.BreakStatement{Token: tok}
+	}
+	return &ast.ContinueStatement{Token: tok}
+}
+
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	statement := &ast.ExpressionStatement{Token: p.currentToken}
 	statement.Expression = p.parseExpression(LOWEST) // ")"를 만나면 parseExpression 루프 종료하면서 리턴
@@ -413,8 +1198,11 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 }
 
 func (p *Parser) noPrefixParseFnError(t token.TokenType) {
-	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
+	if t == token.ILLEGAL {
+		p.panicking = true
+		return
+	}
+	p.addError(p.currentToken.Pos, "no prefix parse function for %s found", t)
 }
 
 func (p *Parser) currentTokenIs(t token.TokenType) bool {
@@ -425,6 +1213,29 @@ func (p *Parser) peekTokenIs(t token.TokenType) bool {
 	return p.peekToken.Type == t
 }
 
+// 닫는 괄호를 기대할 때 사용 (실패하면 여는 괄호 위치를 힌트로 남김)
+func (p *Parser) expectClose(t token.TokenType, open token.Token) bool {
+	if !p.peekTokenIs(t) {
+		p.expectationError(p.peekToken, unclosedHint(open), t)
+		return false
+	}
+	p.nextToken()
+	return true
+}
+
+// 목록의 요소 다음에는 ',' 혹은 닫는 괄호가 나와야 함 (',' 는 소비하고 닫는 괄호는 남겨둠)
+func (p *Parser) expectSeparator(end token.TokenType, open token.Token) bool {
+	if p.peekTokenIs(end) {
+		return true
+	}
+	if !p.peekTokenIs(token.COMMA) {
+		p.expectationError(p.peekToken, unclosedHint(open), token.COMMA, end)
+		return false
+	}
+	p.nextToken()
+	return true
+}
+
 func (p *Parser) expectPeek(t token.TokenType) bool {
 	if !p.peekTokenIs(t) {
 		p.peekError(t)
@@ -460,16 +1271,54 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	return &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal}
 }
 
+// "a ${x} b" 형태의 문자열 파싱 (INTERP_HEAD 토큰에서 호출됨)
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	str := &ast.InterpolatedString{Token: p.currentToken}
+
+	for {
+		// 문자열 조각 (INTERP_HEAD 혹은 INTERP_MID)
+		str.Parts = append(str.Parts, &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal})
+
+		// ${ 다음의 보간식
+		p.nextToken()
+		if p.currentTokenIs(token.INTERP_MID) || p.currentTokenIs(token.INTERP_TAIL) {
+			p.addError(p.currentToken.Pos, "empty expression in string interpolation")
+			return nil
+		}
+		expression := p.parseExpression(LOWEST)
+		if expression == nil {
+			return nil
+		}
+		str.Parts = append(str.Parts, expression)
+
+		switch {
+		case p.peekTokenIs(token.INTERP_MID):
+			p.nextToken()
+		case p.peekTokenIs(token.INTERP_TAIL):
+			p.nextToken()
+			str.Parts = append(str.Parts, &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal})
+			return str
+		default:
+			if !p.peekTokenIs(token.EOF) {
+				p.addError(p.peekToken.Pos, "expected } to close string interpolation, got %s instead", p.peekToken.Type)
+			}
+			return nil
+		}
+	}
+}
+
 func (p *Parser) parseArrayLiteral() ast.Expression {
 	array := &ast.ArrayLiteral{Token: p.currentToken}
 
 	array.Elements = p.parseExpressionList(token.RBRACKET)
+	array.Rbrack = p.currentToken
 
 	return array
 }
 
 func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	list := []ast.Expression{}
+	open := p.currentToken
 
 	if p.peekTokenIs(end) {
 		p.nextToken()
@@ -486,22 +1335,63 @@ func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	}
 
 	// 함수 호출식이 마지막에 end로 닫히는지 확인 ex_ ), ], ...
-	if !p.expectPeek(end) {
+	if !p.expectClose(end, open) {
 		return nil
 	}
 
 	return list
 }
 
+func (p *Parser) parseMemberExpression(left ast.Expression) ast.Expression {
+	expression := &ast.MemberExpression{Token: p.currentToken, Object: left}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	expression.Property = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	return expression
+}
+
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	exp := &ast.IndexExpression{Token: p.currentToken, Left: left}
 
+	// a[:end] 형태의 슬라이스
+	if p.peekTokenIs(token.COLON) {
+		return p.parseSliceExpression(left, exp.Token, nil)
+	}
+
 	p.nextToken()
 	exp.Index = p.parseExpression(LOWEST)
 
-	if !p.expectPeek(token.RBRACKET) {
+	// a[start:] 혹은 a[start:end] 형태의 슬라이스
+	if p.peekTokenIs(token.COLON) {
+		return p.parseSliceExpression(left, exp.Token, exp.Index)
+	}
+
+	if !p.expectClose(token.RBRACKET, exp.Token) {
+		return nil
+	}
+	exp.Rbrack = p.currentToken
+
+	return exp
+}
+
+// '[' 부터 start까지 파싱된 상태에서 호출됨 (peekToken이 ':')
+func (p *Parser) parseSliceExpression(left ast.Expression, open token.Token, start ast.Expression) ast.Expression {
+	exp := &ast.SliceExpression{Token: open, Left: left, Start: start}
+
+	p.nextToken()
+
+	if !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		exp.Stop = p.parseExpression(LOWEST)
+	}
+
+	if !p.expectClose(token.RBRACKET, exp.Token) {
 		return nil
 	}
+	exp.Rbrack = p.currentToken
 
 	return exp
 }
@@ -518,12 +1408,13 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 		p.nextToken()
 		value := p.parseExpression(LOWEST)
 		hash.Pairs[key] = value
-		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+		if !p.expectSeparator(token.RBRACE, hash.Token) {
 			return nil
 		}
 	}
-	if !p.expectPeek(token.RBRACE) {
+	if !p.expectClose(token.RBRACE, hash.Token) {
 		return nil
 	}
+	hash.Rbrace = p.currentToken
 	return hash
 }
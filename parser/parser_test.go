@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"interpreter-go/ast"
 	"interpreter-go/lexer"
+	"interpreter-go/token"
 	"testing"
 )
 
@@ -83,6 +84,62 @@ func testLetStatements(t *testing.T, s ast.Statement, expectedName string) bool
 	return true
 }
 
+func TestDestructuringLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b] = arr;", "let [a, b] = arr;"},
+		{"let [first, ...rest] = [1, 2, 3];", "let [first, ...rest] = [1, 2, 3];"},
+		{"let [...all] = arr;", "let [...all] = arr;"},
+		{"let [] = arr;", "let [] = arr;"},
+		{"let {name, age} = person;", "let {name, age} = person;"},
+		// 중첩 패턴과 키 이름과 다른 변수명
+		{"let [a, [b, _]] = arr;", "let [a, [b, _]] = arr;"},
+		{`let {name: n, "tags": [first]} = person;`, "let {name: n, tags: [first]} = person;"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statements. got=%d", len(program.Statements))
+		}
+		statement, ok := program.Statements[0].(*ast.LetStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.LetStatement. got=%T", program.Statements[0])
+		}
+		if statement.Name != nil || statement.Pattern == nil {
+			t.Fatalf("statement is not a destructuring let. got Name=%v, Pattern=%v", statement.Name, statement.Pattern)
+		}
+		if statement.String() != tt.expected {
+			t.Errorf("statement.String() wrong. expected=%q, got=%q", tt.expected, statement.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [...rest, last] = arr;", "1:13: rest element must be last in array pattern"},
+		{"let [a, +] = arr;", "1:9: unexpected + in pattern"},
+		{"let {1.5: x} = h;", "1:6: Expected next token to be IDENT or STRING, got FLOAT instead"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("%q - wrong errors. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+}
+
 func TestReturnStatements(t *testing.T) {
 	tests := []struct {
 		input         string
@@ -185,6 +242,62 @@ func TestIntegerLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestPrefixedIntegerLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0xFF;", 255},
+		{"0o755;", 493},
+		{"0b1010;", 10},
+		{"1_000_000;", 1000000},
+		{"0xdead_beef;", 3735928559},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		statement := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := statement.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("expression is not *ast.IntegerLiteral. got=%T", statement.Expression)
+		}
+		if literal.Value != test.expected {
+			t.Errorf("literal.Value not %d. got=%d", test.expected, literal.Value)
+		}
+	}
+}
+
+func TestFloatLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"1.5e-3;", 0.0015},
+		{"2e3;", 2000},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		statement := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := statement.Expression.(*ast.FloatLiteral)
+		if !ok {
+			t.Fatalf("expression is not *ast.FloatLiteral. got=%T", statement.Expression)
+		}
+		if literal.Value != test.expected {
+			t.Errorf("literal.Value not %g. got=%g", test.expected, literal.Value)
+		}
+	}
+}
+
 func TestParsingPrefixExpressions(t *testing.T) {
 	prefixTests := []struct {
 		input    string
@@ -414,6 +527,62 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a * b[2], b[1], 2 * [1, 2][1])",
 			"add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))",
 		},
+		{
+			"a + b % c * d",
+			"(a + ((b % c) * d))",
+		},
+		{
+			"a <= b == c >= d",
+			"((a <= b) == (c >= d))",
+		},
+		{
+			"a || b && c",
+			"(a || (b && c))",
+		},
+		{
+			"a == b && c != d || !e",
+			"(((a == b) && (c != d)) || (!e))",
+		},
+		{
+			"a < b + 1 && b >= 0",
+			"((a < (b + 1)) && (b >= 0))",
+		},
+		{
+			"a = b = c + 1",
+			"(a = (b = (c + 1)))",
+		},
+		{
+			"x += y * 2 || z",
+			"(x += ((y * 2) || z))",
+		},
+		{
+			"a[1:2]",
+			"(a[1:2])",
+		},
+		{
+			"xs |> map(f) |> filter(g) |> sum()",
+			"sum(filter(map(xs, f), g))",
+		},
+		{
+			"x + 1 |> f",
+			"f((x + 1))",
+		},
+		{
+			"xs |> len() == 3 && ok",
+			"((len(xs) == 3) && ok)",
+		},
+		{
+			"a = b |> f(c)",
+			"(a = f(b, c))",
+		},
+		{
+			"a[:n - 1][0]",
+			"((a[:(n - 1)])[0])",
+		},
+		{
+			"s[-2:] + s[:]",
+			"((s[(-2):]) + (s[:]))",
+		},
 	}
 
 	for _, test := range tests {
@@ -571,6 +740,297 @@ func TestIfExpression(t *testing.T) {
 	}
 }
 
+func TestWhileStatement(t *testing.T) {
+	input := `while (x < y) { if (x == 3) { break; } continue; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d", 1, len(program.Statements))
+	}
+
+	statement, ok := program.Statements[0].(*ast.WhileStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.WhileStatement. got=%T", program.Statements[0])
+	}
+
+	if !testInfixExpression(t, statement.Condition, "x", "<", "y") {
+		return
+	}
+
+	if len(statement.Body.Statements) != 2 {
+		t.Fatalf("body is not 2 statements. got=%d", len(statement.Body.Statements))
+	}
+
+	if _, ok := statement.Body.Statements[1].(*ast.ContinueStatement); !ok {
+		t.Errorf("Statements[1] is not ast.ContinueStatement. got=%T", statement.Body.Statements[1])
+	}
+
+	if statement.String() != "while(x < y) if(x == 3) break;continue;" {
+		t.Errorf("statement.String() wrong. got=%q", statement.String())
+	}
+
+	// 블록 뒤의 세미콜론은 생략 가능
+	p = New(lexer.New("while (c) { x; }; 2"))
+	program = p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d", 2, len(program.Statements))
+	}
+	if _, ok := program.Statements[0].(*ast.WhileStatement); !ok {
+		t.Errorf("program.Statements[0] is not ast.WhileStatement. got=%T", program.Statements[0])
+	}
+}
+
+func TestTryExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`try { throw error("boom", 1); } catch (e) { e; }`, "try throw error(boom, 1); catch(e) e"},
+		{`try { f(); } finally { cleanup(); }`, "try f() finally cleanup()"},
+		{`try { f(); } catch { 0; } finally { g(); }`, "try f() catch 0 finally g()"},
+		{`throw "oops";`, "throw oops;"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+		}
+		if program.String() != tt.expected {
+			t.Errorf("wrong String(). expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"try { f(); } g();", "1:1: try without catch or finally"},
+		{"try { f(); } catch (1) { }", "1:21: Expected next token to be IDENT, got INT instead"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("%q - wrong errors. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+}
+
+func TestImportExportStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import "lib/strings.mk" as s;`, `import "lib/strings.mk" as s;`},
+		{`import "lib/strings.mk"`, `import "lib/strings.mk";`},
+		{`export let pi = 3.14;`, `export let pi = 3.14;`},
+		{`export fn upper(s) { s }`, `export fn upper(s) s`},
+		{`s.upper(x)`, `(s.upper)(x)`},
+		{`a.b.c + 1`, `(((a.b).c) + 1)`},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("wrong String(). expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{`import strings;`, "1:8: Expected next token to be STRING, got IDENT instead"},
+		{`export 1;`, "1:8: export requires a named let, const or fn declaration, got INT"},
+		{`export let [a, b] = xs;`, "1:8: export requires a named let, const or fn declaration, got LET"},
+		{`fn f() { export let x = 1; }`, "1:10: export must be at top level"},
+		{`s.1`, "1:3: Expected next token to be IDENT, got INT instead"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("%q - wrong errors. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+}
+
+func TestForInStatement(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedKey   string
+		expectedValue string
+		expected      string
+	}{
+		{"for (x in arr) { x; }", "", "x", "for(x in arr) x"},
+		{"for (k, v in h) { break; }", "k", "v", "for(k, v in h) break;"},
+		{"for (c in \"abc\") { continue; }", "", "c", "for(c in abc) continue;"},
+		// 블록 뒤의 세미콜론은 생략 가능
+		{"for (x in [1]) { 1 };", "", "x", "for(x in [1]) 1"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statements. got=%d", len(program.Statements))
+		}
+		statement, ok := program.Statements[0].(*ast.ForInStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ForInStatement. got=%T", program.Statements[0])
+		}
+
+		if test.expectedKey == "" {
+			if statement.Key != nil {
+				t.Errorf("statement.Key is not nil. got=%s", statement.Key)
+			}
+		} else if !testIdentifier(t, statement.Key, test.expectedKey) {
+			return
+		}
+		if !testIdentifier(t, statement.Value, test.expectedValue) {
+			return
+		}
+		if statement.String() != test.expected {
+			t.Errorf("statement.String() wrong. expected=%q, got=%q", test.expected, statement.String())
+		}
+	}
+
+	p := New(lexer.New("for (x in [1]) { 1 }; 2"))
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain 2 statements. got=%d", len(program.Statements))
+	}
+}
+
+func TestMatchExpressionParsing(t *testing.T) {
+	input := `match (x) {
+	0 => "zero",
+	-1 => "minus one",
+	"a" => 1.5,
+	[first, ...rest] => first,
+	{"kind": "circle", r} => r,
+	n if n > 10 => n,
+	_ => false,
+}`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statements. got=%d", len(program.Statements))
+	}
+	statement := program.Statements[0].(*ast.ExpressionStatement)
+	match, ok := statement.Expression.(*ast.MatchExpression)
+	if !ok {
+		t.Fatalf("statement.Expression is not ast.MatchExpression. got=%T", statement.Expression)
+	}
+	if !testIdentifier(t, match.Subject, "x") {
+		return
+	}
+
+	expectedArms := []struct {
+		pattern string
+		guard   string
+		body    string
+	}{
+		{"0", "", "zero"},
+		{"(-1)", "", "minus one"},
+		{"a", "", "1.5"},
+		{"[first, ...rest]", "", "first"},
+		{"{kind: circle, r}", "", "r"},
+		{"n", "(n > 10)", "n"},
+		{"_", "", "false"},
+	}
+
+	if len(match.Arms) != len(expectedArms) {
+		t.Fatalf("match.Arms has wrong length. want=%d, got=%d", len(expectedArms), len(match.Arms))
+	}
+	for i, expected := range expectedArms {
+		arm := match.Arms[i]
+		if arm.Pattern.String() != expected.pattern {
+			t.Errorf("arms[%d] - pattern wrong. expected=%q, got=%q", i, expected.pattern, arm.Pattern.String())
+		}
+		guard := ""
+		if arm.Guard != nil {
+			guard = arm.Guard.String()
+		}
+		if guard != expected.guard {
+			t.Errorf("arms[%d] - guard wrong. expected=%q, got=%q", i, expected.guard, guard)
+		}
+		if arm.Body.String() != expected.body {
+			t.Errorf("arms[%d] - body wrong. expected=%q, got=%q", i, expected.body, arm.Body.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"match (x) { 1 \"one\" }", "1:15: Expected next token to be =>, got STRING instead"},
+		{"match (x) { 1 => 2 3 => 4 }", "1:20: Expected next token to be , or }, got INT instead (unclosed `{` opened at 1:11)"},
+		{"match (x) { (a) => 1 }", "1:13: unexpected ( in pattern"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("%q - wrong errors. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+}
+
+func TestLoopControlOutsideLoop(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"break;", "1:1: break outside of loop"},
+		{"if (true) { continue; }", "1:13: continue outside of loop"},
+		{"for (x in xs) { } break;", "1:19: break outside of loop"},
+		// 함수 본문은 바깥 반복문과 별개
+		{"while (true) { let f = fn() { break; }; }", "1:31: break outside of loop"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("%q - wrong errors. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+}
+
 func TestIfElseExpression(t *testing.T) {
 	input := `if (x < y) { x } else { y }`
 
@@ -706,25 +1166,316 @@ func TestFunctionParameterParsing(t *testing.T) {
 	}
 }
 
-func TestCallExpressionParsing(t *testing.T) {
-	input := "add(1, 2 * 3, 4 + 5);"
+func TestFunctionStatement(t *testing.T) {
+	input := `fn add(a, b = 1) { a + b }
+let sub = fn(a, b) { a - b };
+fn(x) { x }(1);`
+
 	l := lexer.New(input)
 	p := New(l)
 	program := p.ParseProgram()
 	checkParserErrors(t, p)
 
-	if len(program.Statements) != 1 {
-		t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
-			1, len(program.Statements))
+	if len(program.Statements) != 3 {
+		t.Fatalf("program.Statements does not contain 3 statements. got=%d", len(program.Statements))
 	}
 
-	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	statement, ok := program.Statements[0].(*ast.FunctionStatement)
 	if !ok {
-		t.Fatalf("stmt is not ast.ExpressionStatement. got=%T",
-			program.Statements[0])
+		t.Fatalf("program.Statements[0] is not ast.FunctionStatement. got=%T", program.Statements[0])
+	}
+	if !testIdentifier(t, statement.Name, "add") {
+		return
+	}
+	if statement.Function.Name != "add" {
+		t.Errorf("statement.Function.Name is not 'add'. got=%q", statement.Function.Name)
+	}
+	if statement.String() != "fn add(a, b = 1) (a + b)" {
+		t.Errorf("statement.String() wrong. got=%q", statement.String())
 	}
 
-	exp, ok := stmt.Expression.(*ast.CallExpression)
+	// let으로 바인딩한 함수 리터럴은 변수명을 이름으로 가짐
+	let := program.Statements[1].(*ast.LetStatement)
+	if fl := let.Value.(*ast.FunctionLiteral); fl.Name != "sub" {
+		t.Errorf("function literal name is not 'sub'. got=%q", fl.Name)
+	}
+
+	// 이름이 없으면 함수 리터럴 표현식
+	if _, ok := program.Statements[2].(*ast.ExpressionStatement); !ok {
+		t.Errorf("program.Statements[2] is not ast.ExpressionStatement. got=%T", program.Statements[2])
+	}
+}
+
+func TestMacroLiteralParsing(t *testing.T) {
+	input := `macro(x, y) { x + y; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d", 1, len(program.Statements))
+	}
+
+	statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("statement is not ast.ExpressionStatement. got=%T", program.Statements[0])
+	}
+
+	macro, ok := statement.Expression.(*ast.MacroLiteral)
+	if !ok {
+		t.Fatalf("statement.Expression is not ast.MacroLiteral. got=%T", statement.Expression)
+	}
+
+	if len(macro.Parameters) != 2 {
+		t.Fatalf("macro literal parameters wrong. want 2, got=%d", len(macro.Parameters))
+	}
+
+	testLiteralExpression(t, macro.Parameters[0], "x")
+	testLiteralExpression(t, macro.Parameters[1], "y")
+
+	if len(macro.Body.Statements) != 1 {
+		t.Fatalf("macro.Body.Statements has not 1 statements. got=%d", len(macro.Body.Statements))
+	}
+
+	bodyStatement, ok := macro.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("macro body statement is not ast.ExpressionStatement. got=%T", macro.Body.Statements[0])
+	}
+
+	testInfixExpression(t, bodyStatement.Expression, "x", "+", "y")
+
+	p = New(lexer.New("macro(x, y = 1) { x }"))
+	p.ParseProgram()
+	errors := p.Errors()
+	if len(errors) != 1 || errors[0] != "1:1: macro parameters cannot have defaults or rest" {
+		t.Errorf("wrong errors. got=%q", errors)
+	}
+}
+
+func TestLambdaParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+		expected       string
+	}{
+		{"|x| x * 2", []string{"x"}, "fn(x) (x * 2)"},
+		{"|x, y| x + y", []string{"x", "y"}, "fn(x, y) (x + y)"},
+		{"|| 42", []string{}, "fn() 42"},
+		{"|a, b = 1, ...rest| a", []string{"a", "b"}, "fn(a, b = 1, ...rest) a"},
+		// 본문은 가능한 한 길게 파싱됨
+		{"|x| |y| x + y", []string{"x"}, "fn(x) fn(y) (x + y)"},
+		{"|x| if (x) { 1 } else { 2 }", []string{"x"}, "fn(x) ifx 1else 2"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		statement := program.Statements[0].(*ast.ExpressionStatement)
+		function, ok := statement.Expression.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("statement.Expression is not ast.FunctionLiteral. got=%T", statement.Expression)
+		}
+		if len(function.Parameters) != len(test.expectedParams) {
+			t.Fatalf("length parameters wrong. want %d, got %d", len(test.expectedParams), len(function.Parameters))
+		}
+		for i, identifier := range test.expectedParams {
+			testLiteralExpression(t, function.Parameters[i], identifier)
+		}
+		if len(function.Body.Statements) != 1 {
+			t.Fatalf("function.Body.Statements has not 1 statements. got=%d", len(function.Body.Statements))
+		}
+		if function.String() != test.expected {
+			t.Errorf("function.String() wrong. expected=%q, got=%q", test.expected, function.String())
+		}
+	}
+
+	// 람다 호출과 인자로 넘기기
+	precedenceTests := []struct {
+		input    string
+		expected string
+	}{
+		{"map(arr, |x| x * 2)", "map(arr, fn(x) (x * 2))"},
+		{"(|x| x + 1)(2)", "fn(x) (x + 1)(2)"},
+		{"a || b", "(a || b)"},
+	}
+
+	for _, test := range precedenceTests {
+		l := lexer.New(test.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != test.expected {
+			t.Errorf("expected=%q, got=%q", test.expected, program.String())
+		}
+	}
+}
+
+func TestFunctionDefaultAndRestParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn(a, b = 10) { a + b }", "fn(a, b = 10) (a + b)"},
+		{"fn(a = 1, b = a * 2) { b }", "fn(a = 1, b = (a * 2)) b"},
+		{"fn(first, ...rest) { rest }", "fn(first, ...rest) rest"},
+		{"fn(...args) { args }", "fn(...args) args"},
+		{"fn(a, b = 2, ...rest) { a }", "fn(a, b = 2, ...rest) a"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		statement := program.Statements[0].(*ast.ExpressionStatement)
+		function, ok := statement.Expression.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("statement.Expression is not ast.FunctionLiteral. got=%T", statement.Expression)
+		}
+		if function.String() != test.expected {
+			t.Errorf("function.String() wrong. expected=%q, got=%q", test.expected, function.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn(a = 1, b) { b }", "1:11: parameter b without default follows parameter with default"},
+		{"fn(...rest, last) { last }", "1:11: rest parameter must be last"},
+		{"fn(a, 1) { a }", "1:7: Expected next token to be IDENT, got INT instead"},
+		{"fn(a b) { a }", "1:6: Expected next token to be , or ), got IDENT instead (unclosed `(` opened at 1:3)"},
+	}
+
+	for _, test := range errorTests {
+		p := New(lexer.New(test.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != test.expected {
+			t.Errorf("%q - wrong errors. expected=%q, got=%q", test.input, test.expected, errors)
+		}
+	}
+}
+
+func TestAssignExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input            string
+		expectedName     string
+		expectedOperator string
+		expectedValue    interface{}
+	}{
+		{"x = 5;", "x", "=", 5},
+		{"x += 1;", "x", "+=", 1},
+		{"total -= y;", "total", "-=", "y"},
+		{"x *= 2;", "x", "*=", 2},
+		{"x /= 2;", "x", "/=", 2},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statements. got=%d", len(program.Statements))
+		}
+		statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Statements[0])
+		}
+		exp, ok := statement.Expression.(*ast.AssignExpression)
+		if !ok {
+			t.Fatalf("exp is not ast.AssignExpression. got=%T", statement.Expression)
+		}
+		if !testIdentifier(t, exp.Name, test.expectedName) {
+			return
+		}
+		if exp.Operator != test.expectedOperator {
+			t.Fatalf("exp.Operator is not '%s'. got=%s", test.expectedOperator, exp.Operator)
+		}
+		if !testLiteralExpression(t, exp.Value, test.expectedValue) {
+			return
+		}
+	}
+}
+
+func TestConstStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"const x = 5;", "const x = 5;"},
+		{"const add = fn(a, b) { a + b };", "const add = fn(a, b) (a + b);"},
+		{"export const pi = 3.14;", "export const pi = 3.14;"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("wrong String(). expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	p := New(lexer.New("const x = 5;"))
+	program := p.ParseProgram()
+	statement, ok := program.Statements[0].(*ast.LetStatement)
+	if !ok {
+		t.Fatalf("statement is not ast.LetStatement. got=%T", program.Statements[0])
+	}
+	if statement.Token.Type != token.CONST {
+		t.Errorf("statement.Token.Type is not CONST. got=%q", statement.Token.Type)
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"const [a, b] = xs;", "1:7: Expected next token to be IDENT, got [ instead"},
+		{"const x 5;", "1:9: Expected next token to be =, got INT instead"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("%q - wrong errors. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+}
+
+func TestCallExpressionParsing(t *testing.T) {
+	input := "add(1, 2 * 3, 4 + 5);"
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
+			1, len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("stmt is not ast.ExpressionStatement. got=%T",
+			program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.CallExpression)
 	if !ok {
 		t.Fatalf("stmt.Expression is not ast.CallExpression. got=%T",
 			stmt.Expression)
@@ -763,6 +1514,27 @@ func TestStringLiteral(t *testing.T) {
 	}
 }
 
+func TestRawStringLiteral(t *testing.T) {
+	input := "`line1\n\\d+`;"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	statement := program.Statements[0].(*ast.ExpressionStatement)
+	literal, ok := statement.Expression.(*ast.StringLiteral)
+	if !ok {
+		t.Fatalf("expression not *ast.StringLiteral. got=%T", statement.Expression)
+	}
+	if literal.Value != "line1\n\\d+" {
+		t.Errorf("literal.Value not %q. got=%q", "line1\n\\d+", literal.Value)
+	}
+	if literal.String() != "`line1\n\\d+`" {
+		t.Errorf("literal.String() not %q. got=%q", "`line1\n\\d+`", literal.String())
+	}
+}
+
 func TestParsingArrayLiterals(t *testing.T) {
 	input := "[1, 2 * 2, 3 + 3]"
 
@@ -900,3 +1672,268 @@ func TestParsingHashLiteralsWithExpressions(t *testing.T) {
 		testFunc(value)
 	}
 }
+
+func TestNodePositions(t *testing.T) {
+	input := "let add = fn(x, y) {\n  x + y;\n};\nadd(1, [2][0]);"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	tests := []struct {
+		node     ast.Node
+		expected string
+	}{
+		{program, "1:1-4:15"},
+		{program.Statements[0], "1:1-3:2"},
+		{program.Statements[0].(*ast.LetStatement).Value, "1:11-3:2"},
+		{program.Statements[1], "4:1-4:15"},
+		{program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.CallExpression).Arguments[1], "4:8-4:14"},
+	}
+
+	for i, tt := range tests {
+		actual := tt.node.Pos().String() + "-" + tt.node.End().String()
+		if actual != tt.expected {
+			t.Errorf("tests[%d] - position wrong. expected=%q, got=%q", i, tt.expected, actual)
+		}
+	}
+
+	body := program.Statements[0].(*ast.LetStatement).Value.(*ast.FunctionLiteral).Body
+	infix := body.Statements[0].(*ast.ExpressionStatement).Expression
+	if infix.Pos().String() != "2:3" || infix.End().String() != "2:8" {
+		t.Errorf("infix position wrong. got=%s-%s", infix.Pos(), infix.End())
+	}
+}
+
+func TestParserErrorPositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x 5;", "main.mk:1:7: Expected next token to be =, got INT instead"},
+		{"let x = 1;\nlet = 2;", "main.mk:2:5: Expected next token to be IDENT, got = instead"},
+		{"\n\n  )", "main.mk:3:3: no prefix parse function for ) found"},
+		{"let a = [1];\na[0] = 2;", "main.mk:2:1: invalid assignment target (a[0])"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewFile("main.mk", tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected parser errors for %q", tt.input)
+			continue
+		}
+		if errors[0] != tt.expected {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expected, errors[0])
+		}
+	}
+}
+
+func TestDocComments(t *testing.T) {
+	input := `// 두 수를 더함
+// (정수 전용)
+let add = fn(x, y) { x + y; };
+
+// 떨어져 있는 주석
+
+let one = 1; // 뒤에 붙은 주석
+let two = 2;
+`
+
+	l := lexer.New(input)
+	l.SetMode(lexer.ScanComments)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 3 {
+		t.Fatalf("program.Statements does not contain 3 statements. got=%d", len(program.Statements))
+	}
+
+	expectedDocs := []string{"두 수를 더함\n(정수 전용)", "", ""}
+	for i, expected := range expectedDocs {
+		doc := program.Statements[i].(*ast.LetStatement).Doc
+		actual := ""
+		if doc != nil {
+			actual = doc.Text()
+		}
+		if actual != expected {
+			t.Errorf("statements[%d] doc wrong. expected=%q, got=%q", i, expected, actual)
+		}
+	}
+
+	if len(program.Comments) != 3 {
+		t.Errorf("program.Comments does not contain 3 groups. got=%d", len(program.Comments))
+	}
+}
+
+func TestLexerErrorsComeFirst(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedErrors []string
+	}{
+		{
+			"let x = @;",
+			[]string{"1:9: unexpected character '@'"},
+		},
+		{
+			"let x = 1 # 2;\nlet y 10;",
+			[]string{
+				"1:11: unexpected character '#'",
+				"2:7: Expected next token to be =, got INT instead",
+			},
+		},
+		{
+			"let s = \"abc;",
+			[]string{"1:9: unterminated string literal"},
+		},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != len(tt.expectedErrors) {
+			t.Errorf("%q - wrong number of errors. expected=%q, got=%q", tt.input, tt.expectedErrors, errors)
+			continue
+		}
+		for i, expected := range tt.expectedErrors {
+			if errors[i] != expected {
+				t.Errorf("%q - errors[%d] wrong. expected=%q, got=%q", tt.input, i, expected, errors[i])
+			}
+		}
+	}
+}
+
+func TestErrorRecovery(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedErrors []string
+	}{
+		{
+			// 오타 하나에는 에러 하나
+			"let x 5 + 3 * (2;\nlet y = 1;",
+			[]string{"1:7: Expected next token to be =, got INT instead"},
+		},
+		{
+			// 에러 이후의 구문도 계속 파싱해서 다른 에러를 찾음
+			"let = 1;\nlet y = 2;\nlet z 3;",
+			[]string{
+				"1:5: Expected next token to be IDENT, got = instead",
+				"3:7: Expected next token to be =, got INT instead",
+			},
+		},
+		{
+			// 블록 안에서 난 에러는 '}' 에서 복구
+			"if (x) { let = 1 }\nlet y 2;",
+			[]string{
+				"1:14: Expected next token to be IDENT, got = instead",
+				"2:7: Expected next token to be =, got INT instead",
+			},
+		},
+		{
+			"let a = add(1, 2;",
+			[]string{"1:17: Expected next token to be ), got ; instead (unclosed `(` opened at 1:12)"},
+		},
+		{
+			"let a = [1, 2;",
+			[]string{"1:14: Expected next token to be ], got ; instead (unclosed `[` opened at 1:9)"},
+		},
+		{
+			"let h = {\"a\": 1 \"b\": 2};",
+			[]string{"1:17: Expected next token to be , or }, got STRING instead (unclosed `{` opened at 1:9)"},
+		},
+		{
+			"\nlet f = fn(x) {\n  x + 1;\n",
+			[]string{"4:1: Expected next token to be }, got EOF instead (unclosed `{` opened at 2:15)"},
+		},
+		// 대입식의 좌측 파싱이 실패해도 멈추지 않고 첫 에러만 남김
+		{"(1 + ) = 2", []string{"1:6: no prefix parse function for ) found"}},
+		{"{1: } = 2", []string{"1:5: no prefix parse function for } found"}},
+		{"let y = match = 1", []string{"1:15: Expected next token to be (, got = instead"}},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != len(tt.expectedErrors) {
+			t.Errorf("%q - wrong number of errors. expected=%q, got=%q", tt.input, tt.expectedErrors, errors)
+			continue
+		}
+		for i, expected := range tt.expectedErrors {
+			if errors[i] != expected {
+				t.Errorf("%q - errors[%d] wrong. expected=%q, got=%q", tt.input, i, expected, errors[i])
+			}
+		}
+	}
+}
+
+func TestParseErrorFields(t *testing.T) {
+	l := lexer.New("let x = (1 + 2;")
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.ParseErrors()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 error, got=%d", len(errors))
+	}
+
+	err := errors[0]
+	if err.Pos.Line != 1 || err.Pos.Column != 15 {
+		t.Errorf("err.Pos wrong. got=%s", err.Pos)
+	}
+	if len(err.Expected) != 1 || err.Expected[0] != token.RPAREN {
+		t.Errorf("err.Expected wrong. got=%v", err.Expected)
+	}
+	if err.Actual.Type != token.SEMICOLON {
+		t.Errorf("err.Actual wrong. got=%s", err.Actual.Type)
+	}
+	if err.Hint != "unclosed `(` opened at 1:9" {
+		t.Errorf("err.Hint wrong. got=%q", err.Hint)
+	}
+}
+
+func TestInterpolatedString(t *testing.T) {
+	tests := []struct {
+		input         string
+		expected      string
+		expectedParts int
+	}{
+		{`"Hello ${name}!"`, `"Hello ${name}!"`, 3},
+		{`"${a + b * 2} and ${f(x)}"`, `"${(a + (b * 2))} and ${f(x)}"`, 5},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		statement := program.Statements[0].(*ast.ExpressionStatement)
+		str, ok := statement.Expression.(*ast.InterpolatedString)
+		if !ok {
+			t.Fatalf("expression not *ast.InterpolatedString. got=%T", statement.Expression)
+		}
+		if len(str.Parts) != tt.expectedParts {
+			t.Errorf("wrong number of parts. expected=%d, got=%d", tt.expectedParts, len(str.Parts))
+		}
+		if str.String() != tt.expected {
+			t.Errorf("str.String() wrong. expected=%q, got=%q", tt.expected, str.String())
+		}
+	}
+
+	p := New(lexer.New(`"a ${} b"`))
+	p.ParseProgram()
+	if errors := p.Errors(); len(errors) != 1 || errors[0] != "1:6: empty expression in string interpolation" {
+		t.Errorf("wrong errors. got=%q", errors)
+	}
+}
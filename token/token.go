@@ -1,10 +1,40 @@
 package token
 
+import "fmt"
+
 type TokenType string
 
 type Token struct {
 	Type    TokenType
 	Literal string
+	Pos     Position // 토큰의 시작 위치
+	End     Position // 토큰의 바로 다음 위치
+}
+
+// Position : 소스 상의 위치 (go/token.Position 과 같은 형태)
+type Position struct {
+	Filename string // 파일명 (없으면 빈 문자열)
+	Offset   int    // 바이트 오프셋 (0부터 시작)
+	Line     int    // 줄 번호 (1부터 시작)
+	Column   int    // 열 번호 (1부터 시작, 문자 단위)
+}
+
+// IsValid : 줄 번호가 있어야 유효한 위치
+func (pos Position) IsValid() bool { return pos.Line > 0 }
+
+// String : file:line:column, line:column, file 혹은 - 형태로 출력
+func (pos Position) String() string {
+	s := pos.Filename
+	if pos.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+		s += fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	}
+	if s == "" {
+		s = "-"
+	}
+	return s
 }
 
 const (
@@ -14,6 +44,7 @@ const (
 	// 식별자 + 리터럴
 	IDENT = "IDENT" // add, foobar, x, y, ...
 	INT   = "INT"   // 1343456
+	FLOAT = "FLOAT" // 3.14, 1.5e-3
 
 	// 연산자
 	ASSIGN   = "="
@@ -22,13 +53,27 @@ const (
 	BANG     = "!"
 	ASTERISK = "*"
 	SLASH    = "/"
+	PERCENT  = "%"
 
-	LT = "<"
-	GT = ">"
+	LT    = "<"
+	GT    = ">"
+	LT_EQ = "<="
+	GT_EQ = ">="
 
 	EQ     = "=="
 	NOT_EQ = "!="
 
+	AND = "&&"
+	OR  = "||"
+
+	PIPE     = "|"  // 람다의 파라미터 구분자 ex) |x| x * 2
+	PIPELINE = "|>" // 좌측 값을 우측 함수의 첫번째 인자로 넘김 ex) xs |> map(f)
+
+	PLUS_ASSIGN     = "+="
+	MINUS_ASSIGN    = "-="
+	ASTERISK_ASSIGN = "*="
+	SLASH_ASSIGN    = "/="
+
 	// 구분자
 	COMMA     = ","
 	SEMICOLON = ";"
@@ -39,6 +84,9 @@ const (
 	LBRACKET  = "["
 	RBRACKET  = "]"
 	COLON     = ":"
+	ELLIPSIS  = "..."
+	DOT       = "." // 모듈 멤버 접근 ex) strings.upper
+	ARROW     = "=>"
 
 	// 예약어
 	FUNCTION = "FUNCTION"
@@ -48,19 +96,54 @@ const (
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
+	WHILE    = "WHILE"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
+	FOR      = "FOR"
+	IN       = "IN"
+	MATCH    = "MATCH"
+	THROW    = "THROW"
+	TRY      = "TRY"
+	CATCH    = "CATCH"
+	FINALLY  = "FINALLY"
+	MACRO    = "MACRO"
+	IMPORT   = "IMPORT"
+	EXPORT   = "EXPORT"
+	CONST    = "CONST"
 
 	// 확장 기능
-	STRING = "STRING"
+	STRING     = "STRING"
+	RAW_STRING = "RAW_STRING" // `...` (이스케이프 없이 그대로)
+	COMMENT    = "COMMENT"    // 렉서가 ScanComments 모드일 때만 생성됨
+
+	// 문자열 보간 ex) "a ${x} b ${y} c" -> INTERP_HEAD("a ") x INTERP_MID(" b ") y INTERP_TAIL(" c")
+	INTERP_HEAD = "INTERP_HEAD" // 여는 따옴표부터 첫 ${ 까지
+	INTERP_MID  = "INTERP_MID"  // } 부터 다음 ${ 까지
+	INTERP_TAIL = "INTERP_TAIL" // } 부터 닫는 따옴표까지
 )
 
 var keywords = map[string]TokenType{
-	"fn":     FUNCTION,
-	"let":    LET,
-	"true":   TRUE,
-	"false":  FALSE,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
+	"fn":       FUNCTION,
+	"let":      LET,
+	"true":     TRUE,
+	"false":    FALSE,
+	"if":       IF,
+	"else":     ELSE,
+	"return":   RETURN,
+	"while":    WHILE,
+	"break":    BREAK,
+	"continue": CONTINUE,
+	"for":      FOR,
+	"in":       IN,
+	"match":    MATCH,
+	"throw":    THROW,
+	"try":      TRY,
+	"catch":    CATCH,
+	"finally":  FINALLY,
+	"macro":    MACRO,
+	"import":   IMPORT,
+	"export":   EXPORT,
+	"const":    CONST,
 }
 
 func LookupIdent(ident string) TokenType {